@@ -0,0 +1,120 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// Overview summarises all accounts of the customer.
+type Overview struct {
+	Accounts                  []Account `json:"accounts"`
+	NumberOfOrders            int       `json:"numberOfOrders"`
+	NumberOfDeals             int       `json:"numberOfDeals"`
+	NumberOfTransfers         int       `json:"numberOfTransfers"`
+	NumberOfIntradayTransfers int       `json:"numberOfIntradayTransfers"`
+	TotalBuyingPower          float64   `json:"totalBuyingPower"`
+	TotalOwnCapital           float64   `json:"totalOwnCapital"`
+	TotalBalance              float64   `json:"totalBalance"`
+	TotalPerformance          float64   `json:"totalPerformance"`
+	TotalPerformancePercent   float64   `json:"totalPerformancePercent"`
+}
+
+// Account is an account as listed in the Overview.
+type Account struct {
+	AccountID          string  `json:"accountId"`
+	AccountType        string  `json:"accountType"`
+	Name               string  `json:"name"`
+	Active             bool    `json:"active"`
+	Tradable           bool    `json:"tradable"`
+	Depositable        bool    `json:"depositable"`
+	AccountPartlyOwned bool    `json:"accountPartlyOwned"`
+	Attorney           bool    `json:"attorney"`
+	OwnCapital         float64 `json:"ownCapital"`
+	BuyingPower        float64 `json:"buyingPower"`
+	TotalBalance       float64 `json:"totalBalance"`
+	TotalBalanceDue    float64 `json:"totalBalanceDue"`
+	InterestRate       float64 `json:"interestRate"`
+	Performance        float64 `json:"performance"`
+	PerformancePercent float64 `json:"performancePercent"`
+	TotalProfit        float64 `json:"totalProfit"`
+	TotalProfitPercent float64 `json:"totalProfitPercent"`
+}
+
+// AccountOverview holds the details of a single account.
+type AccountOverview struct {
+	AccountID                       string            `json:"accountId"`
+	AccountType                     string            `json:"accountType"`
+	AccountTypeName                 string            `json:"accountTypeName"`
+	Name                            string            `json:"name"`
+	Depositable                     bool              `json:"depositable"`
+	Withdrawable                    bool              `json:"withdrawable"`
+	OwnCapital                      float64           `json:"ownCapital"`
+	BuyingPower                     float64           `json:"buyingPower"`
+	TotalBalance                    float64           `json:"totalBalance"`
+	TotalBalanceDue                 float64           `json:"totalBalanceDue"`
+	InterestRate                    float64           `json:"interestRate"`
+	AccruedInterest                 float64           `json:"accruedInterest"`
+	CreditLimit                     float64           `json:"creditLimit"`
+	AvailableSuperLoanAmount        float64           `json:"availableSuperLoanAmount"`
+	TotalProfit                     float64           `json:"totalProfit"`
+	TotalProfitPercent              float64           `json:"totalProfitPercent"`
+	Performance                     float64           `json:"performance"`
+	PerformancePercent              float64           `json:"performancePercent"`
+	PerformanceSinceOneWeek         float64           `json:"performanceSinceOneWeek"`
+	PerformanceSinceOneWeekPercent  float64           `json:"performanceSinceOneWeekPercent"`
+	PerformanceSinceOneMonth        float64           `json:"performanceSinceOneMonth"`
+	PerformanceSinceOneMonthPercent float64           `json:"performanceSinceOneMonthPercent"`
+	PerformanceSinceOneYear         float64           `json:"performanceSinceOneYear"`
+	PerformanceSinceOneYearPercent  float64           `json:"performanceSinceOneYearPercent"`
+	CurrencyAccounts                []CurrencyBalance `json:"currencyAccounts"`
+	NumberOfOrders                  int               `json:"numberOfOrders"`
+	NumberOfDeals                   int               `json:"numberOfDeals"`
+	NumberOfTransfers               int               `json:"numberOfTransfers"`
+	NumberOfIntradayTransfers       int               `json:"numberOfIntradayTransfers"`
+	CourtageClass                   string            `json:"courtageClass"`
+}
+
+// CurrencyBalance is the balance of an account in one currency.
+type CurrencyBalance struct {
+	Currency string  `json:"currency"`
+	Balance  float64 `json:"balance"`
+}
+
+// GetOverview returns an overview of all accounts.
+func (avanza *Avanza) GetOverview(ctx context.Context) (*Overview, error) {
+	path, err := internal.OverviewPath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var overview Overview
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &overview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &overview, nil
+}
+
+// GetAccountOverview returns the details of the account with accountID.
+func (avanza *Avanza) GetAccountOverview(ctx context.Context, accountID string) (*AccountOverview, error) {
+	if accountID == "" {
+		return nil, fmt.Errorf("%w: account id", ErrInvalidArgument)
+	}
+
+	path, err := internal.AccountOverviewPath.Build(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var overview AccountOverview
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &overview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &overview, nil
+}
@@ -0,0 +1,54 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccounts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/account/overview", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"accounts": [{"accountId": "1234", "accountType": "Investeringssparkonto", "name": "ISK",
+				"ownCapital": 1000.5, "buyingPower": 200, "interestRate": 0.5, "performancePercent": 1.2}],
+			"totalOwnCapital": 1000.5,
+			"numberOfOrders": 2
+		}`))
+	})
+	mux.HandleFunc("/_mobile/account/1234/overview", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"accountId": "1234", "accountTypeName": "ISK", "ownCapital": 1000.5, "buyingPower": 200,
+			"currencyAccounts": [{"currency": "SEK", "balance": 200}, {"currency": "USD", "balance": 10}]
+		}`))
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	t.Run("Assert that the overview is decoded", func(t *testing.T) {
+		overview, err := avanza.GetOverview(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, overview.Accounts, 1)
+		assert.Equal(t, "1234", overview.Accounts[0].AccountID)
+		assert.Equal(t, 1000.5, overview.Accounts[0].OwnCapital)
+		assert.Equal(t, 0.5, overview.Accounts[0].InterestRate)
+		assert.Equal(t, 2, overview.NumberOfOrders)
+	})
+
+	t.Run("Assert that the account overview is decoded", func(t *testing.T) {
+		overview, err := avanza.GetAccountOverview(context.Background(), "1234")
+		require.NoError(t, err)
+
+		assert.Equal(t, 200.0, overview.BuyingPower)
+		assert.Equal(t, []CurrencyBalance{{Currency: "SEK", Balance: 200}, {Currency: "USD", Balance: 10}}, overview.CurrencyAccounts)
+
+		_, err = avanza.GetAccountOverview(context.Background(), "")
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		_, err = avanza.GetAccountOverview(context.Background(), "missing")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
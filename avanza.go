@@ -1,203 +1,413 @@
-package avanza
+package govanza
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha1"
-	"encoding/base32"
-	"encoding/binary"
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/JMrtzsn/govanza/internal"
-	"hash"
-	"math"
+	"log"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
-)
 
-// TODO https://pkg.go.dev/github.com/xlzd/gotp?utm_source=godoc
+	"github.com/JMrtzsn/govanza/internal"
+)
 
 const (
 	BaseURL            = "https://www.avanza.se"
 	MinInactiveMinutes = 30
 	MaxInactiveMinutes = 60 * 24
+
+	socketReconnectLimit = 5
+	socketPath           = "/_push/cometd"
 )
 
 type Avanza struct {
 	AuthenticationTimeout int
 	Session               *http.Client
 
-	Credentials           map[string]string
-	AuthenticationSession string
-	PushSubscriptionID    string
-	CustomerID            string
+	Credentials CredentialsProvider
 
 	Socket *internal.AvanzaSocket
+
+	baseURL     string            // BaseURL unless overridden with WithBaseURL
+	userAgent   string            // Sent as User-Agent if set
+	transport   http.RoundTripper // Set on Session once all options have run, if set
+	logger      *log.Logger       // Logger for logging
+	socketMode  SocketMode        // When the push socket is opened
+	retryPolicy RetryPolicy       // How failed requests are retried
+	rateLimiter *RateLimiter      // Throttles requests and socket messages, if set
+	socketMu    sync.Mutex        // Guards Socket
+
+	sessionMu    sync.RWMutex // Guards auth, renewalTimer and closed
+	auth         Session      // The current authenticated session
+	renewalTimer *time.Timer  // Fires shortly before auth expires
+	closed       bool         // True once Close has been called
+	renewMu      sync.Mutex   // Serialises session renewals
 }
 
-func NewAvanza(credentials map[string]string) (*Avanza, error) {
+// NewAvanza logs in with the given credentials and, unless configured
+// otherwise with WithSocketMode, opens the push socket.
+func NewAvanza(ctx context.Context, credentials CredentialsProvider, options ...Option) (*Avanza, error) {
+	avanza, err := newAvanza(credentials, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := avanza.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	avanza.setSession(auth)
+
+	if avanza.socketMode == SocketEager {
+		if _, err := avanza.PushSocket(ctx); err != nil {
+			avanza.Close()
+			return nil, err
+		}
+	}
+
+	return avanza, nil
+}
+
+// newAvanza applies options to a client that is not logged in yet.
+func newAvanza(credentials CredentialsProvider, options ...Option) (*Avanza, error) {
+	if credentials == nil {
+		return nil, fmt.Errorf("%w: no credentials provider", ErrMissingCredential)
+	}
 
-	session := &http.Client{}
 	avanza := &Avanza{
 		AuthenticationTimeout: MaxInactiveMinutes,
-		Session:               session,
+		Session:               &http.Client{},
 		Credentials:           credentials,
+		baseURL:               BaseURL,
+		logger:                log.Default(),
+		socketMode:            SocketEager,
 	}
 
-	responseBody, err := avanza.authenticate()
-	if err != nil {
-		return nil, err
+	for _, option := range options {
+		if err := option(avanza); err != nil {
+			return nil, err
+		}
 	}
 
-	avanza.AuthenticationSession = responseBody["authenticationSession"].(string)
-	avanza.PushSubscriptionID = responseBody["pushSubscriptionId"].(string)
-	avanza.CustomerID = responseBody["customerId"].(string)
+	if avanza.transport != nil {
+		avanza.Session.Transport = avanza.transport
+	}
+
+	if avanza.Session.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		avanza.Session.Jar = jar
+	}
+
+	return avanza, nil
+}
+
+// PushSocket returns the push socket, opening it first if it is not open yet.
+// The socket connects to the host of the base URL, see WithBaseURL.
+func (avanza *Avanza) PushSocket(ctx context.Context) (*internal.AvanzaSocket, error) {
+	avanza.socketMu.Lock()
+	defer avanza.socketMu.Unlock()
 
-	socket, err := internal.NewAvanzaSocket(avanza.PushSubscriptionID, session)
+	if avanza.Socket != nil {
+		return avanza.Socket, nil
+	}
+
+	if avanza.socketMode == SocketDisabled {
+		return nil, ErrSocketDisabled
+	}
+
+	socket, err := internal.DialAvanzaSocket(ctx, avanza.socketURL(), avanza.CurrentSession().PushSubscriptionID, avanza.cookies(), socketReconnectLimit, avanza.logger)
 	if err != nil {
 		return nil, err
 	}
+
+	if avanza.rateLimiter != nil {
+		socket.Throttle = func(ctx context.Context) error {
+			return avanza.rateLimiter.Wait(ctx, MarketDataGroup)
+		}
+	}
 	avanza.Socket = socket
 
-	return avanza, nil
+	return socket, nil
 }
 
-func (avanza *Avanza) authenticate() (map[string]interface{}, error) {
-	data := map[string]interface{}{
-		"maxInactiveMinutes": avanza.AuthenticationTimeout,
-		"username":           avanza.Credentials["username"],
-		"password":           avanza.Credentials["password"],
+// socketURL returns the push socket URL on the host of the base URL, using
+// ws for http and wss for https.
+func (avanza *Avanza) socketURL() string {
+	if rest, ok := strings.CutPrefix(avanza.baseURL, "http://"); ok {
+		return "ws://" + rest + socketPath
 	}
+	return "wss://" + strings.TrimPrefix(avanza.baseURL, "https://") + socketPath
+}
+
+// Close stops session renewal and closes the push socket.
+func (avanza *Avanza) Close() error {
+	avanza.sessionMu.Lock()
+	avanza.closed = true
+	if avanza.renewalTimer != nil {
+		avanza.renewalTimer.Stop()
+	}
+	avanza.sessionMu.Unlock()
 
-	routeAuthenticationPath := fmt.Sprintf("%s%s", BaseURL, internal.AuthenticationPath.String())
+	avanza.socketMu.Lock()
+	defer avanza.socketMu.Unlock()
 
-	response, err := avanza.sendRequest(http.MethodPost, routeAuthenticationPath, data)
+	if avanza.Socket != nil {
+		return avanza.Socket.Close()
+	}
+	return nil
+}
+
+func (avanza *Avanza) authenticate(ctx context.Context) (Session, error) {
+	credentials, err := avanza.Credentials.Retrieve()
 	if err != nil {
-		return nil, err
+		return Session{}, err
+	}
+
+	err = credentials.Validate()
+	if err != nil {
+		return Session{}, err
+	}
+
+	data := authenticationRequest{
+		MaxInactiveMinutes: avanza.AuthenticationTimeout,
+		Username:           credentials.Username,
+		Password:           credentials.Password,
+	}
+
+	response, err := avanza.sendAuthenticationRequest(ctx, internal.AuthenticationPath, data)
+	if err != nil {
+		return Session{}, err
 	}
 	defer response.Body.Close()
 
-	// TODO implement struct for response
-	var responseBody map[string]interface{}
+	var responseBody authenticationResponse
 	err = json.NewDecoder(response.Body).Decode(&responseBody)
 	if err != nil {
-		return nil, err
+		return Session{}, &AuthenticationError{Route: internal.AuthenticationPath, Err: err}
 	}
 
 	// No second factor required, continue with normal login
-	if _, ok := responseBody["twoFactorLogin"]; !ok {
-		return responseBody, nil
+	if responseBody.TwoFactorLogin == nil {
+		return newSession(internal.AuthenticationPath, response.Header, responseBody.sessionResponse, avanza.AuthenticationTimeout, true)
 	}
 
-	tfaMethod := responseBody["twoFactorLogin"].(map[string]interface{})["method"].(string)
-
+	tfaMethod := responseBody.TwoFactorLogin.Method
 	if tfaMethod != "TOTP" {
-		return nil, fmt.Errorf("unsupported two factor method %s", tfaMethod)
+		return Session{}, &AuthenticationError{
+			Route: internal.AuthenticationPath,
+			Err:   fmt.Errorf("%w %q", ErrUnsupportedTwoFactorMethod, tfaMethod),
+		}
 	}
 
-	return avanza.validate2FA()
+	return avanza.validate2FA(ctx, credentials)
 }
 
-func (avanza *Avanza) validate2FA() (map[string]interface{}, error) {
-	var totpCode string
-	if totpSecret, ok := avanza.Credentials["totpSecret"]; ok {
-		totpCode = GenerateTOTPCode(totpSecret)
-	} else if totpCodeValue, ok := avanza.Credentials["totpCode"]; ok {
-		totpCode = totpCodeValue
-	}
-
+func (avanza *Avanza) validate2FA(ctx context.Context, credentials Credentials) (Session, error) {
+	totpCode := credentials.totpCode()
 	if totpCode == "" {
-		return nil, fmt.Errorf("failed to get TOTP code")
+		return Session{}, &AuthenticationError{Route: internal.TotpPath, Err: ErrMissingTOTPCode}
 	}
 
-	data := map[string]interface{}{
-		"method":   "TOTP",
-		"totpCode": totpCode,
+	data := totpRequest{
+		Method:   "TOTP",
+		TotpCode: totpCode,
 	}
 
-	response, err := avanza.sendRequest(http.MethodPost, RouteTOTPPath, data)
+	response, err := avanza.sendAuthenticationRequest(ctx, internal.TotpPath, data)
 	if err != nil {
-		return nil, err
+		return Session{}, err
 	}
 	defer response.Body.Close()
 
-	var responseBody map[string]interface{}
+	var responseBody sessionResponse
 	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	if err != nil {
+		return Session{}, &AuthenticationError{Route: internal.TotpPath, Err: err}
+	}
+
+	// A one-off TOTP code cannot be used to log in again
+	renewable := credentials.TOTPSecret != ""
+	return newSession(internal.TotpPath, response.Header, responseBody, avanza.AuthenticationTimeout, renewable)
+}
+
+// sendAuthenticationRequest posts data to one of the login routes without
+// any session headers and without triggering session renewal.
+func (avanza *Avanza) sendAuthenticationRequest(ctx context.Context, route internal.Route, data interface{}) (*http.Response, error) {
+	path, err := route.Build()
 	if err != nil {
 		return nil, err
 	}
 
-	return responseBody, nil
+	response, err := avanza.doRequest(ctx, http.MethodPost, path, data, Session{})
+	if err == nil {
+		err = checkResponse(route, response)
+	}
+	if err != nil {
+		return nil, &AuthenticationError{Route: route, Err: err}
+	}
+	return response, nil
 }
 
-func (avanza *Avanza) sendRequest(method string, path string, data map[string]interface{}) (*http.Response, error) {
+// sendRequest sends an authenticated request, retrying temporary failures
+// according to the retry policy.
+func (avanza *Avanza) sendRequest(ctx context.Context, method string, path internal.RoutePath, data interface{}) (*http.Response, error) {
 	method = strings.ToUpper(method)
-	url := fmt.Sprintf("%s%s", BaseURL, path)
+	retryable := avanza.retryPolicy.allows(method, path.Route)
+	start := time.Now()
+
+	for attempt := 0; ; attempt++ {
+		response, err := avanza.sendAttempt(ctx, method, path, data)
+		if err == nil {
+			return response, nil
+		}
+
+		if !retryable || attempt >= avanza.retryPolicy.MaxRetries || !isRetryable(err) {
+			return nil, err
+		}
+
+		wait := avanza.retryPolicy.backoff(attempt, retryAfter(err))
+		if avanza.retryPolicy.MaxElapsed > 0 && time.Since(start)+wait > avanza.retryPolicy.MaxElapsed {
+			return nil, err
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
 
-	var body []byte
-	if data != nil {
-		body, _ = json.Marshal(data)
+// sendAttempt sends an authenticated request once. The session is renewed
+// first if it is about to expire, and the request is repeated once if Avanza
+// answers 401 Unauthorized and the session can be renewed.
+func (avanza *Avanza) sendAttempt(ctx context.Context, method string, path internal.RoutePath, data interface{}) (*http.Response, error) {
+	if avanza.rateLimiter != nil {
+		if err := avanza.rateLimiter.Wait(ctx, routeGroup(path.Route)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := avanza.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	auth := avanza.CurrentSession()
+	response, err := avanza.doRequest(ctx, method, path, data, auth)
 	if err != nil {
 		return nil, err
 	}
 
-	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add("X-AuthenticationSession", avanza.AuthenticationSession)
+	if response.StatusCode == http.StatusUnauthorized && auth.renewable {
+		response.Body.Close()
 
-	if avanza.Socket != nil && avanza.Socket.Connected {
-		request.Header.Add("X-SecurityToken", avanza.Socket.SecurityToken)
-	}
+		if err := avanza.renewSession(ctx, auth.AuthenticationSession); err != nil {
+			return nil, err
+		}
 
-	response, err := avanza.Session.Do(request)
-	if err != nil {
-		return nil, err
+		response, err = avanza.doRequest(ctx, method, path, data, avanza.CurrentSession())
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if response.StatusCode >= 400 {
-		return nil, fmt.Errorf("request failed with status code %d", response.StatusCode)
+	if err := checkResponse(path.Route, response); err != nil {
+		return nil, err
 	}
 
+	avanza.touchSession()
 	return response, nil
 }
 
-func GenerateTOTPCode(secret string) string {
-	totp := NewTOTP(secret, sha1.New, 6, 30)
-	return totp.GenerateCode()
-}
+// requestJSON sends an authenticated request and decodes the JSON response
+// into v, unless v is nil.
+func (avanza *Avanza) requestJSON(ctx context.Context, method string, path internal.RoutePath, data interface{}, v interface{}) error {
+	response, err := avanza.sendRequest(ctx, method, path, data)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if v == nil {
+		return nil
+	}
 
-func NewTOTP(secret string, hashFunc func() hash.Hash, digits int, period int) *TOTP {
-	return &TOTP{
-		Secret:   secret,
-		HashFunc: hashFunc,
-		Digits:   digits,
-		Period:   period,
+	err = json.NewDecoder(response.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path.Route, err)
 	}
+	return nil
 }
 
-type TOTP struct {
-	Secret   string
-	HashFunc func() hash.Hash
-	Digits   int
-	Period   int
+// doRequest sends a single request using the headers of auth.
+func (avanza *Avanza) doRequest(ctx context.Context, method string, path internal.RoutePath, data interface{}, auth Session) (*http.Response, error) {
+	method = strings.ToUpper(method)
+	url := fmt.Sprintf("%s%s", avanza.baseURL, path)
+
+	var body []byte
+	if data != nil {
+		var err error
+		body, err = json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Content-Type", "application/json")
+
+	if avanza.userAgent != "" {
+		request.Header.Set("User-Agent", avanza.userAgent)
+	}
+
+	if auth.AuthenticationSession != "" {
+		request.Header.Add("X-AuthenticationSession", auth.AuthenticationSession)
+	}
+
+	if auth.SecurityToken != "" {
+		request.Header.Add("X-SecurityToken", auth.SecurityToken)
+	}
+
+	return avanza.Session.Do(request)
 }
 
-func (t *TOTP) GenerateCode() string {
-	timeInterval := time.Now().Unix() / int64(t.Period)
-	secret := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(t.Secret)
+// checkResponse returns an *APIError, and closes the body, if the response is not successful.
+func checkResponse(route internal.Route, response *http.Response) error {
+	if response.StatusCode >= 400 {
+		return newAPIError(route, response)
+	}
+	return nil
+}
 
-	hmac := hmac.New(t.HashFunc, secret)
-	binary.Write(hmac, binary.BigEndian, timeInterval)
-	hash := hmac.Sum(nil)
+// cookies returns the session cookies held by the cookie jar formatted as a Cookie header.
+func (avanza *Avanza) cookies() string {
+	if avanza.Session.Jar == nil {
+		return ""
+	}
 
-	offset := hash[len(hash)-1] & 0x0F
-	code := int64(binary.BigEndian.Uint32(hash[offset:offset+4]) & 0x7FFFFFFF)
+	baseURL, err := url.Parse(avanza.baseURL)
+	if err != nil {
+		return ""
+	}
 
-	code %= int64(math.Pow10(t.Digits))
-	format := fmt.Sprintf("%%0%dd", t.Digits)
+	var cookies []string
+	for _, cookie := range avanza.Session.Jar.Cookies(baseURL) {
+		cookies = append(cookies, cookie.String())
+	}
+	return strings.Join(cookies, "; ")
+}
 
-	return fmt.Sprintf(format, code)
+// GenerateTOTPCode returns the current six digit TOTP code for a base32 encoded secret.
+func GenerateTOTPCode(secret string) string {
+	return internal.TOTP(secret, 30, 6)
 }
@@ -0,0 +1,156 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// newTestAvanza returns a client that is not logged in and sends all
+// requests to handler.
+func newTestAvanza(t *testing.T, handler http.Handler, credentials CredentialsProvider, options ...Option) *Avanza {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	options = append([]Option{WithBaseURL(server.URL), WithSocketMode(SocketDisabled)}, options...)
+	avanza, err := newAvanza(credentials, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { avanza.Close() })
+
+	return avanza
+}
+
+func mustBuild(t *testing.T, route internal.Route, params ...interface{}) internal.RoutePath {
+	t.Helper()
+	path, err := route.Build(params...)
+	require.NoError(t, err)
+	return path
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	credentials := Credentials{
+		Username: "user",
+		Password: "pass",
+		TOTPCode: "123456",
+	}
+
+	t.Run("Assert that a TOTP login returns a typed session", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.AuthenticationPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			var body authenticationRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "user", body.Username)
+			assert.Equal(t, MaxInactiveMinutes, body.MaxInactiveMinutes)
+			writeJSON(w, map[string]interface{}{
+				"twoFactorLogin": map[string]string{"method": "TOTP", "transactionId": "tx"},
+			})
+		})
+		mux.HandleFunc(internal.TotpPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			var body totpRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "123456", body.TotpCode)
+			w.Header().Set("X-SecurityToken", "token")
+			http.SetCookie(w, &http.Cookie{Name: "csid", Value: "cookie", Path: "/"})
+			writeJSON(w, map[string]interface{}{
+				"authenticationSession": "auth",
+				"pushSubscriptionId":    "push",
+				"customerId":            "customer",
+				"registrationComplete":  true,
+			})
+		})
+
+		avanza := newTestAvanza(t, mux, credentials)
+		session, err := avanza.authenticate(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "auth", session.AuthenticationSession)
+		assert.Equal(t, "push", session.PushSubscriptionID)
+		assert.Equal(t, "customer", session.CustomerID)
+		assert.True(t, session.RegistrationComplete)
+		assert.Equal(t, "token", session.SecurityToken)
+		assert.False(t, session.ExpiresAt.IsZero())
+	})
+
+	t.Run("Assert that the security token and cookies are sent on later requests", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.AuthenticationPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-SecurityToken", "token")
+			http.SetCookie(w, &http.Cookie{Name: "csid", Value: "cookie", Path: "/"})
+			writeJSON(w, map[string]interface{}{
+				"authenticationSession": "auth",
+				"pushSubscriptionId":    "push",
+				"customerId":            "customer",
+			})
+		})
+		mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "token", r.Header.Get("X-SecurityToken"))
+			assert.Equal(t, "auth", r.Header.Get("X-AuthenticationSession"))
+			cookie, err := r.Cookie("csid")
+			require.NoError(t, err)
+			assert.Equal(t, "cookie", cookie.Value)
+		})
+
+		avanza := newTestAvanza(t, mux, credentials)
+		auth, err := avanza.authenticate(ctx)
+		require.NoError(t, err)
+		avanza.setSession(auth)
+		assert.Equal(t, "csid=cookie", avanza.cookies())
+
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+	})
+
+	t.Run("Assert that a response missing fields returns an AuthenticationError", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.AuthenticationPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, map[string]interface{}{"authenticationSession": "auth"})
+		})
+
+		avanza := newTestAvanza(t, mux, credentials)
+		_, err := avanza.authenticate(ctx)
+
+		var authErr *AuthenticationError
+		require.True(t, errors.As(err, &authErr))
+		assert.Equal(t, internal.AuthenticationPath, authErr.Route)
+		assert.ErrorIs(t, err, ErrIncompleteSession)
+	})
+
+	t.Run("Assert that a non TOTP second factor is rejected", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.AuthenticationPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, map[string]interface{}{
+				"twoFactorLogin": map[string]string{"method": "BANKID"},
+			})
+		})
+
+		avanza := newTestAvanza(t, mux, credentials)
+		_, err := avanza.authenticate(ctx)
+		assert.ErrorIs(t, err, ErrUnsupportedTwoFactorMethod)
+	})
+
+	t.Run("Assert that a cancelled context aborts the login", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		avanza := newTestAvanza(t, loginServer(new(int32)), credentials)
+		_, err := avanza.authenticate(cancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,119 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// Candle is the price movement of an instrument during one interval.
+type Candle struct {
+	Time   time.Time // Start of the interval, in Stockholm
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Candles is price history ordered by time.
+type Candles []Candle
+
+// Closes returns the closing prices of the candles.
+func (c Candles) Closes() []float64 {
+	closes := make([]float64, len(c))
+	for i, candle := range c {
+		closes[i] = candle.Close
+	}
+	return closes
+}
+
+// Volumes returns the traded volumes of the candles.
+func (c Candles) Volumes() []float64 {
+	volumes := make([]float64, len(c))
+	for i, candle := range c {
+		volumes[i] = candle.Volume
+	}
+	return volumes
+}
+
+// chartResolutions lists the resolutions Avanza supports for each period.
+var chartResolutions = map[internal.TimePeriod][]internal.Resolution{
+	internal.Today:       {internal.Minute, internal.TwoMinutes, internal.FiveMinutes, internal.TenMinutes, internal.ThirtyMinutes, internal.Hour},
+	internal.OneWeek:     {internal.TenMinutes, internal.ThirtyMinutes, internal.Hour, internal.Day},
+	internal.OneMonth:    {internal.ThirtyMinutes, internal.Hour, internal.Day, internal.Week},
+	internal.ThreeMonths: {internal.Hour, internal.Day, internal.Week},
+	internal.ThisYear:    {internal.Day, internal.Week, internal.Month},
+	internal.OneYear:     {internal.Day, internal.Week, internal.Month},
+	internal.ThreeYears:  {internal.Day, internal.Week, internal.Month, internal.Quarter},
+	internal.FiveYears:   {internal.Day, internal.Week, internal.Month, internal.Quarter},
+}
+
+// validateChartResolution checks that Avanza supports resolution for period.
+func validateChartResolution(period internal.TimePeriod, resolution internal.Resolution) error {
+	resolutions, ok := chartResolutions[period]
+	if !ok {
+		return fmt.Errorf("%w: time period %q is not supported for chart data", ErrInvalidArgument, period)
+	}
+
+	for _, supported := range resolutions {
+		if supported == resolution {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: resolution %q is not supported for time period %s", ErrInvalidArgument, resolution, period)
+}
+
+// chartdataResponse is returned by internal.ChartdataPath.
+type chartdataResponse struct {
+	OHLC []struct {
+		Timestamp         Timestamp `json:"timestamp"`
+		Open              float64   `json:"open"`
+		High              float64   `json:"high"`
+		Low               float64   `json:"low"`
+		Close             float64   `json:"close"`
+		TotalVolumeTraded float64   `json:"totalVolumeTraded"`
+	} `json:"ohlc"`
+}
+
+// GetChartData returns the price history of the orderbook with orderbookID
+// over period, with one candle per resolution. Combinations Avanza does not
+// support, e.g. minute candles over five years, are rejected before the
+// request is sent.
+func (avanza *Avanza) GetChartData(ctx context.Context, orderbookID string, period internal.TimePeriod, resolution internal.Resolution) (Candles, error) {
+	if orderbookID == "" {
+		return nil, fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	}
+	if err := validateChartResolution(period, resolution); err != nil {
+		return nil, err
+	}
+
+	path, err := internal.ChartdataPath.Build(orderbookID, strings.ToLower(period.String()), strings.ToLower(resolution.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	var response chartdataResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make(Candles, 0, len(response.OHLC))
+	for _, ohlc := range response.OHLC {
+		candles = append(candles, Candle{
+			Time:   ohlc.Timestamp.In(Stockholm),
+			Open:   ohlc.Open,
+			High:   ohlc.High,
+			Low:    ohlc.Low,
+			Close:  ohlc.Close,
+			Volume: ohlc.TotalVolumeTraded,
+		})
+	}
+
+	return candles, nil
+}
@@ -0,0 +1,46 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChartData(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/price-chart/stock/5247", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		writeJSON(w, map[string]interface{}{"ohlc": []map[string]interface{}{
+			{"timestamp": 1704182400000, "open": 240, "high": 245, "low": 238, "close": 244, "totalVolumeTraded": 1200},
+			{"timestamp": 1704268800000, "open": 244, "high": 250, "low": 243, "close": 249, "totalVolumeTraded": 900},
+		}})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	t.Run("Assert that candles are returned in Stockholm time", func(t *testing.T) {
+		candles, err := avanza.GetChartData(ctx, "5247", PeriodOneMonth, ResolutionDay)
+		require.NoError(t, err)
+		assert.Equal(t, "resolution=day&timePeriod=one_month", query)
+
+		require.Len(t, candles, 2)
+		assert.Equal(t, time.Date(2024, 1, 2, 9, 0, 0, 0, Stockholm), candles[0].Time)
+		assert.Equal(t, Stockholm, candles[0].Time.Location())
+		assert.Equal(t, 1200.0, candles[0].Volume)
+		assert.Equal(t, []float64{244, 249}, candles.Closes())
+	})
+
+	t.Run("Assert that unsupported period and resolution combinations are rejected", func(t *testing.T) {
+		_, err := avanza.GetChartData(ctx, "5247", PeriodFiveYears, ResolutionMinute)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = avanza.GetChartData(ctx, "5247", PeriodToday, ResolutionWeek)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = avanza.GetChartData(ctx, "5247", PeriodThreeYearsRolling, ResolutionDay)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,165 @@
+package govanza
+
+import (
+	"bufio"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Credentials are the details needed to log in to Avanza. Either TOTPSecret
+// or a one-off TOTPCode must be set, if both are set the secret is used.
+type Credentials struct {
+	Username   string `json:"username" yaml:"username"`
+	Password   string `json:"password" yaml:"password"`
+	TOTPSecret string `json:"totpSecret" yaml:"totpSecret"`
+	TOTPCode   string `json:"totpCode" yaml:"totpCode"`
+}
+
+// CredentialsProvider supplies the credentials used to log in. Retrieve is
+// called for every login, including when the session is renewed.
+type CredentialsProvider interface {
+	Retrieve() (Credentials, error)
+}
+
+// Retrieve returns the credentials as they are, which lets a Credentials
+// value be used as a static CredentialsProvider.
+func (c Credentials) Retrieve() (Credentials, error) {
+	return c, nil
+}
+
+// Validate reports the first missing or malformed field.
+func (c Credentials) Validate() error {
+	switch {
+	case c.Username == "":
+		return fmt.Errorf("%w: username", ErrMissingCredential)
+	case c.Password == "":
+		return fmt.Errorf("%w: password", ErrMissingCredential)
+	case c.TOTPSecret == "" && c.TOTPCode == "":
+		return ErrMissingTOTPCode
+	}
+
+	if c.TOTPSecret != "" {
+		_, err := base32.StdEncoding.DecodeString(strings.ToUpper(c.TOTPSecret))
+		if err != nil {
+			return fmt.Errorf("totpSecret is not valid base32: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// totpCode returns the code to send for the second factor.
+func (c Credentials) totpCode() string {
+	if c.TOTPSecret != "" {
+		return GenerateTOTPCode(c.TOTPSecret)
+	}
+	return c.TOTPCode
+}
+
+// EnvCredentials reads credentials from the environment variables
+// <Prefix>_USERNAME, <Prefix>_PASSWORD, <Prefix>_TOTP_SECRET and
+// <Prefix>_TOTP_CODE. Prefix defaults to AVANZA.
+type EnvCredentials struct {
+	Prefix string
+}
+
+func (e EnvCredentials) Retrieve() (Credentials, error) {
+	prefix := e.Prefix
+	if prefix == "" {
+		prefix = "AVANZA"
+	}
+
+	return Credentials{
+		Username:   os.Getenv(prefix + "_USERNAME"),
+		Password:   os.Getenv(prefix + "_PASSWORD"),
+		TOTPSecret: os.Getenv(prefix + "_TOTP_SECRET"),
+		TOTPCode:   os.Getenv(prefix + "_TOTP_CODE"),
+	}, nil
+}
+
+// FileCredentials reads credentials from a JSON or YAML file, chosen by the
+// file extension. The file must not be accessible by group or others, i.e.
+// it should have mode 0600.
+type FileCredentials struct {
+	Path string
+}
+
+func (f FileCredentials) Retrieve() (Credentials, error) {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return Credentials{}, fmt.Errorf("credentials file %s has mode %#o, expected 0600", f.Path, info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var credentials Credentials
+	switch strings.ToLower(filepath.Ext(f.Path)) {
+	case ".json":
+		err = json.Unmarshal(data, &credentials)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &credentials)
+	default:
+		return Credentials{}, fmt.Errorf("unsupported credentials file format %q", filepath.Ext(f.Path))
+	}
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse credentials file %s: %w", f.Path, err)
+	}
+
+	return credentials, nil
+}
+
+// PromptCredentials takes the username and password from Base and asks for a
+// one-off TOTP code on every login. In defaults to os.Stdin and Out to os.Stderr.
+type PromptCredentials struct {
+	Base CredentialsProvider
+	In   io.Reader
+	Out  io.Writer
+}
+
+func (p PromptCredentials) Retrieve() (Credentials, error) {
+	if p.Base == nil {
+		return Credentials{}, fmt.Errorf("%w: base provider", ErrMissingCredential)
+	}
+
+	credentials, err := p.Base.Retrieve()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	in, out := p.In, p.Out
+	if in == nil {
+		in = os.Stdin
+	}
+	if out == nil {
+		out = os.Stderr
+	}
+
+	_, err = fmt.Fprint(out, "Avanza TOTP code: ")
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return Credentials{}, fmt.Errorf("failed to read TOTP code: %w", err)
+	}
+
+	credentials.TOTPSecret = ""
+	credentials.TOTPCode = strings.TrimSpace(line)
+	return credentials, nil
+}
@@ -0,0 +1,72 @@
+package govanza
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials(t *testing.T) {
+	t.Run("Assert that missing fields are reported up front", func(t *testing.T) {
+		assert.ErrorIs(t, Credentials{Password: "pass", TOTPCode: "1"}.Validate(), ErrMissingCredential)
+		assert.ErrorIs(t, Credentials{Username: "user", TOTPCode: "1"}.Validate(), ErrMissingCredential)
+		assert.ErrorIs(t, Credentials{Username: "user", Password: "pass"}.Validate(), ErrMissingTOTPCode)
+		assert.Error(t, Credentials{Username: "user", Password: "pass", TOTPSecret: "not base32!"}.Validate())
+		assert.NoError(t, Credentials{Username: "user", Password: "pass", TOTPSecret: "JBSWY3DPEHPK3PXP"}.Validate())
+	})
+
+	t.Run("Assert that environment variables are read with a prefix", func(t *testing.T) {
+		t.Setenv("TEST_USERNAME", "user")
+		t.Setenv("TEST_PASSWORD", "pass")
+		t.Setenv("TEST_TOTP_SECRET", "JBSWY3DPEHPK3PXP")
+
+		credentials, err := EnvCredentials{Prefix: "TEST"}.Retrieve()
+		require.NoError(t, err)
+		assert.Equal(t, Credentials{Username: "user", Password: "pass", TOTPSecret: "JBSWY3DPEHPK3PXP"}, credentials)
+	})
+
+	t.Run("Assert that JSON and YAML files are read", func(t *testing.T) {
+		dir := t.TempDir()
+		files := map[string]string{
+			"credentials.json": `{"username": "user", "password": "pass", "totpSecret": "JBSWY3DPEHPK3PXP"}`,
+			"credentials.yaml": "username: user\npassword: pass\ntotpSecret: JBSWY3DPEHPK3PXP\n",
+		}
+
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			credentials, err := FileCredentials{Path: path}.Retrieve()
+			require.NoError(t, err, name)
+			assert.Equal(t, Credentials{Username: "user", Password: "pass", TOTPSecret: "JBSWY3DPEHPK3PXP"}, credentials, name)
+		}
+	})
+
+	t.Run("Assert that a file readable by others is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "credentials.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{}`), 0o644))
+
+		_, err := FileCredentials{Path: path}.Retrieve()
+		assert.ErrorContains(t, err, "expected 0600")
+	})
+
+	t.Run("Assert that a prompted TOTP code replaces the secret", func(t *testing.T) {
+		var out bytes.Buffer
+		provider := PromptCredentials{
+			Base: Credentials{Username: "user", Password: "pass", TOTPSecret: "JBSWY3DPEHPK3PXP"},
+			In:   strings.NewReader("654321\n"),
+			Out:  &out,
+		}
+
+		credentials, err := provider.Retrieve()
+		require.NoError(t, err)
+		assert.Equal(t, "654321", credentials.TOTPCode)
+		assert.Empty(t, credentials.TOTPSecret)
+		assert.Contains(t, out.String(), "TOTP code")
+	})
+}
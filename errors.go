@@ -0,0 +1,145 @@
+package govanza
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+var (
+	ErrUnsupportedTwoFactorMethod = errors.New("unsupported two factor method")
+	ErrMissingCredential          = errors.New("missing credential")
+	ErrMissingTOTPCode            = errors.New("no totpSecret or totpCode in credentials")
+	ErrIncompleteSession          = errors.New("session response is missing a field")
+	ErrSessionExpired             = errors.New("session has expired and cannot be renewed")
+	ErrSocketDisabled             = errors.New("push socket is disabled")
+	ErrInvalidArgument            = errors.New("invalid argument")
+	ErrOrderRejected              = errors.New("order rejected")
+	ErrOrderFilled                = errors.New("order already filled")
+)
+
+// AuthenticationError is returned when a step of the login flow fails.
+type AuthenticationError struct {
+	Route internal.Route // The authentication endpoint that failed
+	Err   error          // The underlying cause
+}
+
+func (e *AuthenticationError) Error() string {
+	return fmt.Sprintf("authentication failed at %s: %v", e.Route, e.Err)
+}
+
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrNotFound     = errors.New("not found")
+)
+
+// maxErrorBodySize limits how much of an error response is kept in APIError.
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when Avanza answers a request with a 4xx or 5xx status.
+// It matches ErrUnauthorized, ErrRateLimited, ErrNotFound and, for 401 outside
+// the login flow, ErrSessionExpired with errors.Is.
+type APIError struct {
+	StatusCode int            // The HTTP status code
+	Route      internal.Route // The route that was requested
+	Method     string         // The HTTP method that was used
+	Code       string         // Avanza's error code, if the body contained one
+	Message    string         // Avanza's error message, if the body contained one
+	RetryAfter time.Duration  // Parsed from the Retry-After header, zero if absent
+	Body       []byte         // The raw response body, truncated to 64 KiB
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%s %s failed with status code %d", e.Method, e.Route, e.StatusCode)
+	if e.Code != "" {
+		message += " (" + e.Code + ")"
+	}
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	return message
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrSessionExpired:
+		return e.StatusCode == http.StatusUnauthorized &&
+			e.Route != internal.AuthenticationPath && e.Route != internal.TotpPath
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// apiErrorBody covers the error payloads returned by the different Avanza APIs.
+type apiErrorBody struct {
+	ErrorCode    string `json:"errorCode"`
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// newAPIError reads and closes the body of an unsuccessful response.
+func newAPIError(route internal.Route, response *http.Response) *APIError {
+	defer response.Body.Close()
+
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Route:      route,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+	}
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+	}
+
+	apiErr.Body, _ = io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+
+	var body apiErrorBody
+	if json.Unmarshal(apiErr.Body, &body) == nil {
+		apiErr.Code = firstNonEmpty(body.ErrorCode, body.Code)
+		apiErr.Message = firstNonEmpty(body.Message, body.ErrorMessage)
+	}
+
+	return apiErr
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
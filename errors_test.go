@@ -0,0 +1,54 @@
+package govanza
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("Assert that error responses are parsed into an APIError", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			writeJSON(w, map[string]string{"errorCode": "TOO_MANY", "message": "slow down"})
+		})
+
+		avanza := newTestAvanza(t, mux, Credentials{})
+		_, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.Equal(t, internal.OverviewPath, apiErr.Route)
+		assert.Equal(t, http.MethodGet, apiErr.Method)
+		assert.Equal(t, "TOO_MANY", apiErr.Code)
+		assert.Equal(t, "slow down", apiErr.Message)
+		assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Assert that status codes match the sentinel errors", func(t *testing.T) {
+		assert.ErrorIs(t, &APIError{StatusCode: http.StatusNotFound}, ErrNotFound)
+		assert.ErrorIs(t, &APIError{StatusCode: http.StatusUnauthorized, Route: internal.OverviewPath}, ErrUnauthorized)
+		assert.ErrorIs(t, &APIError{StatusCode: http.StatusUnauthorized, Route: internal.OverviewPath}, ErrSessionExpired)
+		assert.NotErrorIs(t, &APIError{StatusCode: http.StatusUnauthorized, Route: internal.AuthenticationPath}, ErrSessionExpired)
+	})
+
+	t.Run("Assert that Retry-After is parsed as seconds and as a date", func(t *testing.T) {
+		now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+		assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+		assert.Equal(t, time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+		assert.Zero(t, parseRetryAfter("", now))
+		assert.Zero(t, parseRetryAfter("soon", now))
+	})
+}
@@ -0,0 +1,255 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// FundQuantity is how much of a fund to sell, either a number of units or an
+// amount of money. Use FundVolume or FundAmount to create one.
+type FundQuantity struct {
+	Volume float64
+	Amount float64
+}
+
+// FundVolume sells the given number of fund units.
+func FundVolume(volume float64) FundQuantity {
+	return FundQuantity{Volume: volume}
+}
+
+// FundAmount sells fund units worth the given amount.
+func FundAmount(amount float64) FundQuantity {
+	return FundQuantity{Amount: amount}
+}
+
+// Validate checks that exactly one of Volume and Amount is set and positive.
+func (q FundQuantity) Validate() error {
+	switch {
+	case q.Volume != 0 && q.Amount != 0:
+		return fmt.Errorf("%w: set either volume or amount, not both", ErrInvalidArgument)
+	case q.Volume == 0 && q.Amount == 0:
+		return fmt.Errorf("%w: volume or amount is required", ErrInvalidArgument)
+	case q.Volume < 0 || q.Amount < 0 || math.IsNaN(q.Volume) || math.IsNaN(q.Amount):
+		return fmt.Errorf("%w: volume and amount must be positive", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// fundOrderRequest is the body sent to internal.OrderPlacePathBuyFund and
+// internal.OrderPlacePathSellFund.
+type fundOrderRequest struct {
+	AccountID   string  `json:"accountId"`
+	OrderbookID string  `json:"orderbookId"`
+	Amount      float64 `json:"amount,omitempty"`
+	Volume      float64 `json:"volume,omitempty"`
+}
+
+// BuyFund buys fund units worth amount. Fund orders are executed at the next
+// NAV, so the result only tells whether the order was accepted. If Avanza
+// rejects the order the result is returned together with an error wrapping
+// ErrOrderRejected.
+func (avanza *Avanza) BuyFund(ctx context.Context, accountID, orderbookID string, amount float64) (*OrderResult, error) {
+	if amount <= 0 || math.IsNaN(amount) {
+		return nil, fmt.Errorf("%w: amount %v must be positive", ErrInvalidArgument, amount)
+	}
+
+	return avanza.placeFundOrder(ctx, internal.OrderPlacePathBuyFund, fundOrderRequest{
+		AccountID:   accountID,
+		OrderbookID: orderbookID,
+		Amount:      amount,
+	})
+}
+
+// SellFund sells a number of fund units or units worth an amount, see
+// FundVolume and FundAmount. Errors are reported like in BuyFund.
+func (avanza *Avanza) SellFund(ctx context.Context, accountID, orderbookID string, quantity FundQuantity) (*OrderResult, error) {
+	if err := quantity.Validate(); err != nil {
+		return nil, err
+	}
+
+	return avanza.placeFundOrder(ctx, internal.OrderPlacePathSellFund, fundOrderRequest{
+		AccountID:   accountID,
+		OrderbookID: orderbookID,
+		Amount:      quantity.Amount,
+		Volume:      quantity.Volume,
+	})
+}
+
+func (avanza *Avanza) placeFundOrder(ctx context.Context, route internal.Route, data fundOrderRequest) (*OrderResult, error) {
+	switch {
+	case data.AccountID == "":
+		return nil, fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case data.OrderbookID == "":
+		return nil, fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	}
+
+	path, err := route.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var response orderResponse
+	err = avanza.requestJSON(ctx, http.MethodPost, path, data, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.result()
+}
+
+// FundInfo is the fund guide data of a fund.
+type FundInfo struct {
+	OrderbookID       string
+	ISIN              string
+	Name              string
+	Description       string
+	Currency          string
+	FundCompany       string
+	Category          string
+	StartDate         Date
+	NAV               float64
+	NAVDate           Date
+	Capital           float64
+	NumberOfOwners    int
+	Fees              FundFees
+	RiskLevel         int // 1 to 7
+	MorningstarRating int // 1 to 5, 0 if unrated
+	StandardDeviation float64
+	SharpeRatio       float64
+	Holdings          []FundAllocation
+	Sectors           []FundAllocation
+	Countries         []FundAllocation
+	NAVHistory        []NAVPoint
+	Development       map[internal.TimePeriod]float64 // In percent, only periods reported by Avanza
+}
+
+// FundAllocation is the share of a fund invested in one holding, sector or country.
+type FundAllocation struct {
+	Name        string  `json:"name"`
+	Percent     float64 `json:"y"`
+	CountryCode string  `json:"countryCode"`
+}
+
+// NAVPoint is the net asset value of a fund on one day.
+type NAVPoint struct {
+	Date Date    `json:"date"`
+	NAV  float64 `json:"nav"`
+}
+
+// fundResponse is returned by internal.FundPath.
+type fundResponse struct {
+	OrderbookID string `json:"orderbookId"`
+	ISIN        string `json:"isin"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Currency    string `json:"currency"`
+	FundCompany struct {
+		Name string `json:"name"`
+	} `json:"fundCompany"`
+	Category               string           `json:"category"`
+	StartDate              Date             `json:"startDate"`
+	NAV                    float64          `json:"nav"`
+	NAVDate                Date             `json:"navDate"`
+	Capital                float64          `json:"capital"`
+	NumberOfOwners         int              `json:"numberOfOwners"`
+	OngoingCharges         float64          `json:"ongoingCharges"`
+	ManagementFee          float64          `json:"managementFee"`
+	BuyFee                 float64          `json:"buyFee"`
+	SellFee                float64          `json:"sellFee"`
+	TransactionFee         float64          `json:"transactionFee"`
+	Risk                   int              `json:"risk"`
+	Rating                 int              `json:"rating"`
+	StandardDeviation      float64          `json:"standardDeviation"`
+	SharpeRatio            float64          `json:"sharpeRatio"`
+	HoldingChartData       []FundAllocation `json:"holdingChartData"`
+	SectorChartData        []FundAllocation `json:"sectorChartData"`
+	CountryChartData       []FundAllocation `json:"countryChartData"`
+	NAVHistory             []NAVPoint       `json:"navHistory"`
+	DevelopmentOneDay      *float64         `json:"developmentOneDay"`
+	DevelopmentOneWeek     *float64         `json:"developmentOneWeek"`
+	DevelopmentOneMonth    *float64         `json:"developmentOneMonth"`
+	DevelopmentThreeMonths *float64         `json:"developmentThreeMonths"`
+	DevelopmentThisYear    *float64         `json:"developmentThisYear"`
+	DevelopmentOneYear     *float64         `json:"developmentOneYear"`
+	DevelopmentThreeYears  *float64         `json:"developmentThreeYears"`
+	DevelopmentFiveYears   *float64         `json:"developmentFiveYears"`
+}
+
+func (r fundResponse) info() *FundInfo {
+	info := &FundInfo{
+		OrderbookID:    r.OrderbookID,
+		ISIN:           r.ISIN,
+		Name:           r.Name,
+		Description:    r.Description,
+		Currency:       r.Currency,
+		FundCompany:    r.FundCompany.Name,
+		Category:       r.Category,
+		StartDate:      r.StartDate,
+		NAV:            r.NAV,
+		NAVDate:        r.NAVDate,
+		Capital:        r.Capital,
+		NumberOfOwners: r.NumberOfOwners,
+		Fees: FundFees{
+			OngoingCharges: r.OngoingCharges,
+			ManagementFee:  r.ManagementFee,
+			BuyFee:         r.BuyFee,
+			SellFee:        r.SellFee,
+			TransactionFee: r.TransactionFee,
+		},
+		RiskLevel:         r.Risk,
+		MorningstarRating: r.Rating,
+		StandardDeviation: r.StandardDeviation,
+		SharpeRatio:       r.SharpeRatio,
+		Holdings:          r.HoldingChartData,
+		Sectors:           r.SectorChartData,
+		Countries:         r.CountryChartData,
+		NAVHistory:        r.NAVHistory,
+		Development:       make(map[internal.TimePeriod]float64),
+	}
+
+	developments := map[internal.TimePeriod]*float64{
+		internal.Today:       r.DevelopmentOneDay,
+		internal.OneWeek:     r.DevelopmentOneWeek,
+		internal.OneMonth:    r.DevelopmentOneMonth,
+		internal.ThreeMonths: r.DevelopmentThreeMonths,
+		internal.ThisYear:    r.DevelopmentThisYear,
+		internal.OneYear:     r.DevelopmentOneYear,
+		internal.ThreeYears:  r.DevelopmentThreeYears,
+		internal.FiveYears:   r.DevelopmentFiveYears,
+	}
+	for period, development := range developments {
+		if development != nil {
+			info.Development[period] = *development
+		}
+	}
+
+	return info
+}
+
+// GetFundInfo returns the fund guide data of the fund with orderbookID.
+func (avanza *Avanza) GetFundInfo(ctx context.Context, orderbookID string) (*FundInfo, error) {
+	if orderbookID == "" {
+		return nil, fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	}
+
+	path, err := internal.FundPath.Build(orderbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response fundResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	info := response.info()
+	if info.OrderbookID == "" {
+		info.OrderbookID = orderbookID
+	}
+	return info, nil
+}
@@ -0,0 +1,83 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundOrders(t *testing.T) {
+	var bodies []map[string]interface{}
+	record := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		writeJSON(w, map[string]string{"orderRequestStatus": "SUCCESS", "orderId": "f1"})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/fund-guide/fund-order-page/buy", record)
+	mux.HandleFunc("/_api/fund-guide/fund-order-page/sell", record)
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	t.Run("Assert that funds are bought by amount and sold by volume or amount", func(t *testing.T) {
+		result, err := avanza.BuyFund(ctx, "1", "878733", 500)
+		require.NoError(t, err)
+		assert.Equal(t, "f1", result.OrderID)
+
+		_, err = avanza.SellFund(ctx, "1", "878733", FundVolume(2.5))
+		require.NoError(t, err)
+		_, err = avanza.SellFund(ctx, "1", "878733", FundAmount(100))
+		require.NoError(t, err)
+
+		require.Len(t, bodies, 3)
+		assert.Equal(t, map[string]interface{}{"accountId": "1", "orderbookId": "878733", "amount": 500.0}, bodies[0])
+		assert.Equal(t, map[string]interface{}{"accountId": "1", "orderbookId": "878733", "volume": 2.5}, bodies[1])
+		assert.Equal(t, map[string]interface{}{"accountId": "1", "orderbookId": "878733", "amount": 100.0}, bodies[2])
+	})
+
+	t.Run("Assert that invalid fund orders are rejected before they are sent", func(t *testing.T) {
+		_, err := avanza.BuyFund(ctx, "1", "878733", 0)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = avanza.SellFund(ctx, "1", "878733", FundQuantity{Volume: 1, Amount: 1})
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = avanza.SellFund(ctx, "1", "878733", FundQuantity{})
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = avanza.BuyFund(ctx, "", "878733", 100)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
+
+func TestGetFundInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/fund-guide/guide/878733", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"name": "Avanza Global", "nav": 187.3, "navDate": "2024-03-01T00:00:00",
+			"ongoingCharges": 0.05, "buyFee": 0, "risk": 4, "rating": 3,
+			"fundCompany":       map[string]string{"name": "Avanza Fonder"},
+			"countryChartData":  []map[string]interface{}{{"name": "USA", "y": 63.2, "countryCode": "US"}},
+			"navHistory":        []map[string]interface{}{{"date": "2024-02-29", "nav": 186.1}},
+			"developmentOneDay": -0.4, "developmentOneYear": 21.7,
+		})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	t.Run("Assert that fund guide data is returned", func(t *testing.T) {
+		info, err := avanza.GetFundInfo(context.Background(), "878733")
+		require.NoError(t, err)
+		assert.Equal(t, "878733", info.OrderbookID)
+		assert.Equal(t, "Avanza Fonder", info.FundCompany)
+		assert.Equal(t, "2024-03-01", info.NAVDate.String())
+		assert.Equal(t, 0.05, info.Fees.OngoingCharges)
+		assert.Equal(t, 4, info.RiskLevel)
+		assert.Equal(t, 3, info.MorningstarRating)
+		assert.Equal(t, []FundAllocation{{Name: "USA", Percent: 63.2, CountryCode: "US"}}, info.Countries)
+		assert.Equal(t, 186.1, info.NAVHistory[0].NAV)
+		assert.Equal(t, map[TimePeriod]float64{PeriodToday: -0.4, PeriodOneYear: 21.7}, info.Development)
+	})
+}
@@ -0,0 +1,208 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// Instrument is returned by GetInstrument and GetInstrumentDetails. The
+// concrete type depends on the instrument type: *StockInstrument,
+// *FundInstrument, *CertificateInstrument, *WarrantInstrument,
+// *BondInstrument, or *OtherInstrument for the remaining types.
+type Instrument interface {
+	// Info returns the fields shared by all instrument types.
+	Info() InstrumentInfo
+
+	common() *InstrumentInfo
+}
+
+// InstrumentInfo holds the fields shared by all instrument types.
+type InstrumentInfo struct {
+	OrderbookID  string                  `json:"orderbookId"`
+	InstrumentID string                  `json:"instrumentId"`
+	Name         string                  `json:"name"`
+	ISIN         string                  `json:"isin"`
+	Type         internal.InstrumentType `json:"type"`
+	Tradable     bool                    `json:"tradable"`
+	Listing      Listing                 `json:"listing"`
+	Quote        Quote                   `json:"quote"`
+}
+
+func (i InstrumentInfo) Info() InstrumentInfo {
+	return i
+}
+
+func (i *InstrumentInfo) common() *InstrumentInfo {
+	return i
+}
+
+// Listing describes where an instrument is traded.
+type Listing struct {
+	ShortName       string `json:"shortName"`
+	TickerSymbol    string `json:"tickerSymbol"`
+	CountryCode     string `json:"countryCode"`
+	Currency        string `json:"currency"`
+	MarketPlaceCode string `json:"marketPlaceCode"`
+	MarketPlaceName string `json:"marketPlaceName"`
+	TickSizeListID  string `json:"tickSizeListId"`
+}
+
+// Quote is the latest price information of an instrument.
+type Quote struct {
+	Last              float64   `json:"last"`
+	Buy               float64   `json:"buy"`
+	Sell              float64   `json:"sell"`
+	Highest           float64   `json:"highest"`
+	Lowest            float64   `json:"lowest"`
+	Change            float64   `json:"change"`
+	ChangePercent     float64   `json:"changePercent"`
+	TotalValueTraded  float64   `json:"totalValueTraded"`
+	TotalVolumeTraded float64   `json:"totalVolumeTraded"`
+	TimeOfLast        Timestamp `json:"timeOfLast"`
+	Updated           Timestamp `json:"updated"`
+}
+
+// StockInstrument is a stock with its key ratios.
+type StockInstrument struct {
+	InstrumentInfo
+	KeyRatios KeyRatios `json:"keyIndicators"`
+}
+
+// KeyRatios are the key figures of a stock.
+type KeyRatios struct {
+	NumberOfOwners     int     `json:"numberOfOwners"`
+	DirectYield        float64 `json:"directYield"`
+	Volatility         float64 `json:"volatility"`
+	Beta               float64 `json:"beta"`
+	PriceEarningsRatio float64 `json:"priceEarningsRatio"`
+	PriceSalesRatio    float64 `json:"priceSalesRatio"`
+	ReturnOnEquity     float64 `json:"returnOnEquity"`
+	EarningsPerShare   Money   `json:"earningsPerShare"`
+	EquityPerShare     Money   `json:"equityPerShare"`
+	MarketCapital      Money   `json:"marketCapital"`
+	DividendsPerYear   int     `json:"dividendsPerYear"`
+	ReportDate         Date    `json:"reportDate"`
+}
+
+// FundInstrument is a fund with its fees.
+type FundInstrument struct {
+	InstrumentInfo
+	Fees      FundFees `json:"fees"`
+	RiskLevel int      `json:"risk"`
+	NAV       float64  `json:"nav"`
+	NAVDate   Date     `json:"navDate"`
+}
+
+// FundFees are the fees charged by a fund, in percent.
+type FundFees struct {
+	OngoingCharges float64 `json:"ongoingCharges"`
+	ManagementFee  float64 `json:"managementFee"`
+	BuyFee         float64 `json:"buyFee"`
+	SellFee        float64 `json:"sellFee"`
+	TransactionFee float64 `json:"transactionFee"`
+}
+
+// Underlying is the instrument a derivative tracks.
+type Underlying struct {
+	OrderbookID    string                  `json:"orderbookId"`
+	Name           string                  `json:"name"`
+	InstrumentType internal.InstrumentType `json:"instrumentType"`
+	Currency       string                  `json:"currency"`
+}
+
+// CertificateInstrument is a leveraged certificate.
+type CertificateInstrument struct {
+	InstrumentInfo
+	Leverage   float64    `json:"leverage"`
+	Direction  string     `json:"direction"` // "LONG" or "SHORT"
+	Issuer     string     `json:"issuer"`
+	EndDate    Date       `json:"endDate"`
+	Underlying Underlying `json:"underlying"`
+}
+
+// WarrantInstrument is a warrant.
+type WarrantInstrument struct {
+	InstrumentInfo
+	StrikePrice float64    `json:"strikePrice"`
+	OptionType  string     `json:"optionType"` // "CALL" or "PUT"
+	Parity      float64    `json:"parity"`
+	Issuer      string     `json:"issuer"`
+	EndDate     Date       `json:"endDate"`
+	Underlying  Underlying `json:"underlying"`
+}
+
+// BondInstrument is a bond.
+type BondInstrument struct {
+	InstrumentInfo
+	CouponRate      float64 `json:"couponRate"`
+	CouponFrequency string  `json:"couponFrequency"`
+	NominalAmount   float64 `json:"nominalAmount"`
+	MaturityDate    Date    `json:"maturityDate"`
+	Issuer          string  `json:"issuer"`
+}
+
+// OtherInstrument is any instrument type without a payload of its own.
+type OtherInstrument struct {
+	InstrumentInfo
+}
+
+// newInstrument returns the payload to decode an instrument of instrumentType into.
+func newInstrument(instrumentType internal.InstrumentType) Instrument {
+	switch instrumentType {
+	case internal.Stock:
+		return &StockInstrument{}
+	case internal.Fund:
+		return &FundInstrument{}
+	case internal.Certificate:
+		return &CertificateInstrument{}
+	case internal.Warrant:
+		return &WarrantInstrument{}
+	case internal.Bond:
+		return &BondInstrument{}
+	default:
+		return &OtherInstrument{}
+	}
+}
+
+// GetInstrument returns the instrument of instrumentType with instrumentID.
+// Type switch on the result to get the fields specific to the type.
+func (avanza *Avanza) GetInstrument(ctx context.Context, instrumentType internal.InstrumentType, instrumentID string) (Instrument, error) {
+	return avanza.getInstrument(ctx, internal.InstrumentPath, instrumentType, instrumentID)
+}
+
+// GetInstrumentDetails is like GetInstrument but uses the details endpoint,
+// which fills in the type specific fields more completely.
+func (avanza *Avanza) GetInstrumentDetails(ctx context.Context, instrumentType internal.InstrumentType, instrumentID string) (Instrument, error) {
+	return avanza.getInstrument(ctx, internal.InstrumentDetailsPath, instrumentType, instrumentID)
+}
+
+func (avanza *Avanza) getInstrument(ctx context.Context, route internal.Route, instrumentType internal.InstrumentType, instrumentID string) (Instrument, error) {
+	switch {
+	case instrumentType == internal.Any || instrumentType.String() == "":
+		return nil, fmt.Errorf("%w: instrument type is required", ErrInvalidArgument)
+	case instrumentID == "":
+		return nil, fmt.Errorf("%w: instrument id", ErrInvalidArgument)
+	}
+
+	path, err := route.Build(instrumentType, instrumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	instrument := newInstrument(instrumentType)
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, instrument)
+	if err != nil {
+		return nil, err
+	}
+
+	// The type is missing from some responses
+	info := instrument.common()
+	info.Type = instrumentType
+	if info.OrderbookID == "" {
+		info.OrderbookID = instrumentID
+	}
+	return instrument, nil
+}
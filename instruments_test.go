@@ -0,0 +1,65 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInstrument(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/market-guide/stock/5247", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"orderbookId": "5247", "name": "Investor B", "isin": "SE0015811963",
+			"listing":       map[string]interface{}{"tickerSymbol": "INVE B", "currency": "SEK"},
+			"quote":         map[string]interface{}{"last": 250.5, "timeOfLast": 1700000000000},
+			"keyIndicators": map[string]interface{}{"priceEarningsRatio": 6.5, "marketCapital": map[string]interface{}{"value": 7.6e11, "currency": "SEK"}},
+		})
+	})
+	mux.HandleFunc("/_api/market-guide/warrant/1001/details", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"name": "VOLV 2C 200", "strikePrice": 200, "optionType": "CALL"})
+	})
+	mux.HandleFunc("/_api/market-guide/index/19002", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"orderbookId": "19002", "name": "OMX Stockholm 30"})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	t.Run("Assert that a stock is returned with its key ratios", func(t *testing.T) {
+		instrument, err := avanza.GetInstrument(ctx, InstrumentStock, "5247")
+		require.NoError(t, err)
+
+		stock, ok := instrument.(*StockInstrument)
+		require.True(t, ok)
+		assert.Equal(t, "INVE B", stock.Listing.TickerSymbol)
+		assert.Equal(t, 6.5, stock.KeyRatios.PriceEarningsRatio)
+		assert.Equal(t, Money{Value: 7.6e11, Currency: "SEK"}, stock.KeyRatios.MarketCapital)
+		assert.Equal(t, Stockholm, stock.Quote.TimeOfLast.Location())
+		assert.Equal(t, InstrumentStock, instrument.Info().Type)
+	})
+
+	t.Run("Assert that warrant details are returned with the strike price", func(t *testing.T) {
+		instrument, err := avanza.GetInstrumentDetails(ctx, InstrumentWarrant, "1001")
+		require.NoError(t, err)
+
+		warrant, ok := instrument.(*WarrantInstrument)
+		require.True(t, ok)
+		assert.Equal(t, 200.0, warrant.StrikePrice)
+		assert.Equal(t, "1001", warrant.OrderbookID)
+	})
+
+	t.Run("Assert that other instrument types share the common fields", func(t *testing.T) {
+		instrument, err := avanza.GetInstrument(ctx, InstrumentIndex, "19002")
+		require.NoError(t, err)
+		assert.IsType(t, &OtherInstrument{}, instrument)
+		assert.Equal(t, "OMX Stockholm 30", instrument.Info().Name)
+	})
+
+	t.Run("Assert that an instrument type is required", func(t *testing.T) {
+		_, err := avanza.GetInstrument(ctx, InstrumentAny, "5247")
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
@@ -370,6 +370,9 @@ const (
 	OverviewPath
 	PositionsPath
 	PriceAlertPath
+	PriceAlertCreatePath
+	PriceAlertDeletePath
+	StopLossDeletePath
 	StopLossPath
 	TotpPath
 	TransactionsPath
@@ -387,7 +390,7 @@ func (r Route) String() string {
 	case AuthenticationPath:
 		return "/_api/authentication/sessions/usercredentials"
 	case ChartdataPath:
-		return "/_api/price-chart/stock/{}"
+		return "/_api/price-chart/stock/{}?timePeriod={}&resolution={}"
 	case CurrentOffersPath:
 		return "/_api/customer-offer/currentoffers/"
 	case DealsAndOrdersPath:
@@ -440,6 +443,12 @@ func (r Route) String() string {
 		return "/_mobile/account/positions"
 	case PriceAlertPath:
 		return "/_cqbe/marketing/service/alert/{}"
+	case PriceAlertCreatePath:
+		return "/_cqbe/marketing/service/alert/{}/create"
+	case PriceAlertDeletePath:
+		return "/_cqbe/marketing/service/alert/{}/{}"
+	case StopLossDeletePath:
+		return "/_api/trading-critical/rest/stoploss/{}/{}"
 	case StopLossPath:
 		return "/_api/trading-critical/rest/stoploss"
 	case TotpPath:
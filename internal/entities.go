@@ -3,16 +3,18 @@ package internal
 import "time"
 
 type StopLossTrigger struct {
-	Type       StopLossTriggerType
-	Value      float64
-	ValidUntil time.Time
+	Type                      StopLossTriggerType `json:"type"`
+	Value                     float64             `json:"value"`
+	ValueType                 StopLossPriceType   `json:"valueType"`
+	ValidUntil                time.Time           `json:"validUntil"` // Only the date is sent
+	TriggerOnMarketMakerQuote bool                `json:"triggerOnMarketMakerQuote"`
 }
 
 type StopLossOrderEvent struct {
-	Type                OrderType
-	Price               float64
-	Volume              float64
-	ValidDays           int
-	PriceType           StopLossPriceType
-	ShortSellingAllowed bool
+	Type                OrderType         `json:"type"`
+	Price               float64           `json:"price"`
+	Volume              float64           `json:"volume"`
+	ValidDays           int               `json:"validDays"`
+	PriceType           StopLossPriceType `json:"priceType"`
+	ShortSellingAllowed bool              `json:"shortSellingAllowed"`
 }
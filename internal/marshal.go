@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Stockholm must be loadable without a system time zone database
+)
+
+// Stockholm is the time zone Avanza reports times and dates in.
+var Stockholm = mustLoadLocation("Europe/Stockholm")
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// ParseInstrumentType parses an instrument type in either case, e.g. "stock" or "STOCK".
+func ParseInstrumentType(s string) (InstrumentType, error) {
+	for t := Stock; t < Any; t++ {
+		if strings.EqualFold(t.String(), s) {
+			return t, nil
+		}
+	}
+	return Any, fmt.Errorf("unknown instrument type %q", s)
+}
+
+// MarshalJSON encodes the instrument type in upper case as used by the Avanza API.
+func (t InstrumentType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(t.String()))
+}
+
+// UnmarshalJSON decodes empty and unknown instrument types as Any, so that a
+// new type added by Avanza does not break decoding of a whole response.
+func (t *InstrumentType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseInstrumentType(s)
+	if err != nil {
+		parsed = Any
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the order type as "BUY" or "SELL".
+func (t OrderType) MarshalJSON() ([]byte, error) {
+	if t.String() == "" {
+		return nil, fmt.Errorf("unknown order type %d", int(t))
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *OrderType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	for _, candidate := range []OrderType{BUY, SELL} {
+		if strings.EqualFold(candidate.String(), s) {
+			*t = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown order type %q", s)
+}
+
+const dateLayout = "2006-01-02"
+
+// stopLossTriggerJSON mirrors StopLossTrigger with ValidUntil as a date string.
+type stopLossTriggerJSON struct {
+	Type                      StopLossTriggerType `json:"type"`
+	Value                     float64             `json:"value"`
+	ValueType                 StopLossPriceType   `json:"valueType"`
+	ValidUntil                string              `json:"validUntil"`
+	TriggerOnMarketMakerQuote bool                `json:"triggerOnMarketMakerQuote"`
+}
+
+// MarshalJSON encodes ValidUntil as its YYYY-MM-DD date in Stockholm.
+func (t StopLossTrigger) MarshalJSON() ([]byte, error) {
+	return json.Marshal(stopLossTriggerJSON{
+		Type:                      t.Type,
+		Value:                     t.Value,
+		ValueType:                 t.ValueType,
+		ValidUntil:                t.ValidUntil.In(Stockholm).Format(dateLayout),
+		TriggerOnMarketMakerQuote: t.TriggerOnMarketMakerQuote,
+	})
+}
+
+func (t *StopLossTrigger) UnmarshalJSON(data []byte) error {
+	var decoded stopLossTriggerJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	var validUntil time.Time
+	if decoded.ValidUntil != "" {
+		var err error
+		validUntil, err = time.ParseInLocation(dateLayout, decoded.ValidUntil[:min(len(decoded.ValidUntil), len(dateLayout))], Stockholm)
+		if err != nil {
+			return fmt.Errorf("invalid stop loss valid until %q: %w", decoded.ValidUntil, err)
+		}
+	}
+
+	*t = StopLossTrigger{
+		Type:                      decoded.Type,
+		Value:                     decoded.Value,
+		ValueType:                 decoded.ValueType,
+		ValidUntil:                validUntil,
+		TriggerOnMarketMakerQuote: decoded.TriggerOnMarketMakerQuote,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the trigger type using String.
+func (t StopLossTriggerType) MarshalJSON() ([]byte, error) {
+	if t.String() == "" {
+		return nil, fmt.Errorf("unknown stop loss trigger type %d", int(t))
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *StopLossTriggerType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	for candidate := FollowDownwards; candidate <= MoreOrEqual; candidate++ {
+		if strings.EqualFold(candidate.String(), s) {
+			*t = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown stop loss trigger type %q", s)
+}
+
+// MarshalJSON encodes the price type using String.
+func (t StopLossPriceType) MarshalJSON() ([]byte, error) {
+	if t.String() == "" {
+		return nil, fmt.Errorf("unknown stop loss price type %d", int(t))
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *StopLossPriceType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	for candidate := Monetary; candidate <= Percentage; candidate++ {
+		if strings.EqualFold(candidate.String(), s) {
+			*t = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown stop loss price type %q", s)
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentTypeJSON(t *testing.T) {
+	t.Run("Assert that instrument types round trip in upper case", func(t *testing.T) {
+		data, err := json.Marshal(ExchangeTradedFund)
+		require.NoError(t, err)
+		assert.Equal(t, `"EXCHANGE_TRADED_FUND"`, string(data))
+
+		var parsed InstrumentType
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		assert.Equal(t, ExchangeTradedFund, parsed)
+	})
+
+	t.Run("Assert that unknown instrument types are decoded as Any", func(t *testing.T) {
+		parsed := Stock
+		require.NoError(t, json.Unmarshal([]byte(`"SPACESHIP"`), &parsed))
+		assert.Equal(t, Any, parsed)
+	})
+}
+
+func TestStopLossTriggerJSON(t *testing.T) {
+	t.Run("Assert that valid until is encoded as its date in Stockholm", func(t *testing.T) {
+		trigger := StopLossTrigger{
+			Type:       FollowDownwards,
+			Value:      5,
+			ValueType:  Percentage,
+			ValidUntil: time.Date(2030, 6, 28, 23, 30, 0, 0, time.UTC),
+		}
+
+		data, err := json.Marshal(trigger)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"validUntil":"2030-06-29"`)
+
+		var decoded StopLossTrigger
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, time.Date(2030, 6, 29, 0, 0, 0, 0, Stockholm), decoded.ValidUntil)
+	})
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const placeholder = "{}"
+
+// RoutePath is a Route with its placeholders filled in.
+type RoutePath struct {
+	Route Route  // The route the path was built from
+	Path  string // The escaped path, including the query string
+}
+
+func (p RoutePath) String() string {
+	return p.Path
+}
+
+// Build fills the {} placeholders of the route with params, in order.
+// Placeholders in the path are path escaped and placeholders in the query
+// string are encoded with url.Values. A []string parameter is joined with
+// commas. An error is returned if the number of params does not match the
+// number of placeholders.
+func (r Route) Build(params ...interface{}) (RoutePath, error) {
+	template := r.String()
+	if template == "" {
+		return RoutePath{}, fmt.Errorf("unknown route %d", int(r))
+	}
+
+	path, query, hasQuery := strings.Cut(template, "?")
+
+	segments := strings.Split(path, placeholder)
+	expected := len(segments) - 1
+
+	var pairs []string
+	if hasQuery {
+		pairs = strings.Split(query, "&")
+		for _, pair := range pairs {
+			if strings.Contains(pair, placeholder) {
+				expected++
+			}
+		}
+	}
+
+	if len(params) != expected {
+		return RoutePath{}, fmt.Errorf("route %s expects %d parameters, got %d", template, expected, len(params))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(segments[0])
+	for i, segment := range segments[1:] {
+		builder.WriteString(url.PathEscape(formatParam(params[i])))
+		builder.WriteString(segment)
+	}
+
+	if hasQuery {
+		values := url.Values{}
+		next := len(segments) - 1
+		for _, pair := range pairs {
+			key, value, _ := strings.Cut(pair, "=")
+			if value == placeholder {
+				value = formatParam(params[next])
+				next++
+			} else if strings.Contains(value, placeholder) {
+				return RoutePath{}, fmt.Errorf("route %s has an unsupported placeholder in %q", template, pair)
+			}
+			values.Add(key, value)
+		}
+		builder.WriteString("?")
+		builder.WriteString(values.Encode())
+	}
+
+	return RoutePath{Route: r, Path: builder.String()}, nil
+}
+
+func formatParam(param interface{}) string {
+	switch param := param.(type) {
+	case string:
+		return param
+	case []string:
+		return strings.Join(param, ",")
+	case fmt.Stringer:
+		return param.String()
+	default:
+		return fmt.Sprint(param)
+	}
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteBuild(t *testing.T) {
+	t.Run("Assert that path and query placeholders are filled and escaped", func(t *testing.T) {
+		path, err := InstrumentSearchPath.Build(Stock, "volvo b&c", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "/_mobile/market/search/stock?limit=10&query=volvo+b%26c", path.String())
+		assert.Equal(t, InstrumentSearchPath, path.Route)
+
+		path, err = InstrumentPath.Build(Fund, "a/b")
+		require.NoError(t, err)
+		assert.Equal(t, "/_api/market-guide/fund/a%2Fb", path.String())
+	})
+
+	t.Run("Assert that list parameters are joined with commas", func(t *testing.T) {
+		path, err := InsightsPath.Build(OneYear, []string{"1", "2"})
+		require.NoError(t, err)
+		assert.Equal(t, "/_api/insights-development/?accountIds=1%2C2&timePeriod=ONE_YEAR", path.String())
+	})
+
+	t.Run("Assert that the number of parameters is validated", func(t *testing.T) {
+		_, err := InstrumentPath.Build(Stock)
+		assert.Error(t, err)
+
+		_, err = OverviewPath.Build("extra")
+		assert.Error(t, err)
+
+		_, err = Route(-1).Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("Assert that every route template can be built", func(t *testing.T) {
+		for route := AccountOverviewPath; route <= WatchlistsPath; route++ {
+			path, err := route.Build()
+			if err == nil {
+				assert.Equal(t, route.String(), path.String())
+				continue
+			}
+
+			_, err = route.Build(make([]interface{}, placeholders(route))...)
+			assert.NoError(t, err, route.String())
+		}
+	})
+}
+
+func placeholders(route Route) int {
+	count := 0
+	template := route.String()
+	for i := 0; i+1 < len(template); i++ {
+		if template[i:i+2] == placeholder {
+			count++
+		}
+	}
+	return count
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/gorilla/websocket"
@@ -8,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -16,26 +18,34 @@ const (
 
 // AvanzaSocket represents the Avanza WebSocket client.
 type AvanzaSocket struct {
-	sync.Mutex                             // Mutex for thread safety
-	ClientID           string              // Initialized in handshake message
-	Conn               *websocket.Conn     // The WebSocket connection
-	Connected          bool                // True if the socket is Connected
-	MessageCount       int                 // Keeps check of the number of messages sent
-	Logger             *log.Logger         // Logger for logging
-	PushSubscriptionID string              // TODO: where does this come from?
-	Subscriptions      map[string]struct { // Map of subscriptions
+	sync.Mutex                                     // Guards the connection state, subscriptions and writes to Conn
+	ClientID           string                      // Initialized in handshake message
+	Conn               *websocket.Conn             // The WebSocket connection
+	Connected          bool                        // True if the socket is Connected
+	MessageCount       int                         // Keeps check of the number of messages sent
+	Logger             *log.Logger                 // Logger for logging
+	PushSubscriptionID string                      // TODO: where does this come from?
+	Throttle           func(context.Context) error // Called before every message is sent, if set
+	Subscriptions      map[string]struct {         // Map of subscriptions
 		Callback func(string, map[string]interface{})
 		ClientID string
 	}
 }
 
 // NewAvanzaSocket creates a new AvanzaSocket instance with the given logger.
-// If logger is nil, the default logger is used.
-func NewAvanzaSocket(pushSubscriptionID, cookies string, reconnectLimit int, logger *log.Logger) (*AvanzaSocket, error) {
+// If logger is nil, the default logger is used. ctx bounds the dial and the
+// initial handshake.
+func NewAvanzaSocket(ctx context.Context, pushSubscriptionID, cookies string, reconnectLimit int, logger *log.Logger) (*AvanzaSocket, error) {
+	return DialAvanzaSocket(ctx, webSocketURL, pushSubscriptionID, cookies, reconnectLimit, logger)
+}
+
+// DialAvanzaSocket is like NewAvanzaSocket but connects to url instead of
+// Avanza's push server, e.g. a local stand-in.
+func DialAvanzaSocket(ctx context.Context, url, pushSubscriptionID, cookies string, reconnectLimit int, logger *log.Logger) (*AvanzaSocket, error) {
 	headers := make(http.Header)
 	headers.Add("Cookie", cookies)
 
-	conn, _, err := websocket.DefaultDialer.Dial(webSocketURL, headers)
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -54,19 +64,44 @@ func NewAvanzaSocket(pushSubscriptionID, cookies string, reconnectLimit int, log
 		Logger:             logger,
 	}
 
-	err = s.sendHandshakeMessage(s.PushSubscriptionID)
+	err = s.sendHandshakeMessage(ctx, s.PushSubscriptionID)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 
 	return s, nil
 }
 
-// Listen establishes the WebSocket connection and starts listening for messages.
-func (s *AvanzaSocket) Listen() error {
+// Listen establishes the WebSocket connection and starts listening for messages
+// until reading fails or ctx is cancelled, in which case ctx.Err() is returned.
+// The connection can not be read from again after Listen has returned.
+func (s *AvanzaSocket) Listen(ctx context.Context) error {
+	s.Lock()
+	conn := s.Conn
+	s.Unlock()
+	if conn == nil {
+		return errors.New("socket is closed")
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Unblocks the pending ReadMessage
+			_ = conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
 	for {
-		_, message, err := s.Conn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			s.Logger.Println("Failed to read message from websocket:", err)
 			return err
 		}
@@ -89,11 +124,11 @@ func (s *AvanzaSocket) Listen() error {
 			s.Logger.Println("Message:", msg)
 			switch channel {
 			case "/meta/disconnect":
-				err = s.handleDisconnectMessage()
+				err = s.handleDisconnectMessage(ctx)
 			case "/meta/handshake":
-				err = s.handleHandshakeMessage(msg)
+				err = s.handleHandshakeMessage(ctx, msg)
 			case "/meta/connect":
-				err = s.handleConnectMessage(msg)
+				err = s.handleConnectMessage(ctx, msg)
 			case "/meta/subscribe":
 				err = s.handleSubscribeMessage(msg)
 			default:
@@ -109,6 +144,9 @@ func (s *AvanzaSocket) Listen() error {
 
 // Close closes the WebSocket connection.
 func (s *AvanzaSocket) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
 	if s.Conn != nil {
 		err := s.Conn.Close()
 		s.Conn = nil
@@ -118,12 +156,12 @@ func (s *AvanzaSocket) Close() error {
 }
 
 // SubscribeToID subscribes to a channel with a single ID.
-func (s *AvanzaSocket) SubscribeToID(channel, id string, callback func(string, map[string]interface{})) error {
-	return s.SubscribeToIDs(channel, []string{id}, callback)
+func (s *AvanzaSocket) SubscribeToID(ctx context.Context, channel, id string, callback func(string, map[string]interface{})) error {
+	return s.SubscribeToIDs(ctx, channel, []string{id}, callback)
 }
 
 // SubscribeToIDs subscribes to a channel with multiple IDs.
-func (s *AvanzaSocket) SubscribeToIDs(channel string, ids []string, callback func(string, map[string]interface{})) error {
+func (s *AvanzaSocket) SubscribeToIDs(ctx context.Context, channel string, ids []string, callback func(string, map[string]interface{})) error {
 	if len(ids) == 0 {
 		return errors.New("no IDs provided")
 	}
@@ -139,14 +177,35 @@ func (s *AvanzaSocket) SubscribeToIDs(channel string, ids []string, callback fun
 	}
 
 	subscriptionString := "/" + channel + "/" + strings.Join(ids, ",")
-	return s.socketSubscribe(subscriptionString, callback)
+	return s.socketSubscribe(ctx, subscriptionString, callback)
 }
 
-func (s *AvanzaSocket) send(message interface{}) error {
+// send writes message, using the deadline of ctx as the write deadline.
+func (s *AvanzaSocket) send(ctx context.Context, message interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.Throttle != nil {
+		if err := s.Throttle(ctx); err != nil {
+			return err
+		}
+	}
+
 	s.Lock()
 	defer s.Unlock()
 
-	err := s.Conn.WriteJSON([]interface{}{message})
+	if s.Conn == nil {
+		return errors.New("socket is closed")
+	}
+
+	deadline, _ := ctx.Deadline()
+	err := s.Conn.SetWriteDeadline(deadline)
+	if err != nil {
+		return err
+	}
+
+	err = s.Conn.WriteJSON([]interface{}{message})
 	if err != nil {
 		return err
 	}
@@ -155,18 +214,20 @@ func (s *AvanzaSocket) send(message interface{}) error {
 	return nil
 }
 
-func (s *AvanzaSocket) sendConnectMessage() error {
+func (s *AvanzaSocket) sendConnectMessage(ctx context.Context) error {
+	s.Lock()
 	message := map[string]interface{}{
 		"channel":        "/meta/connect",
 		"clientId":       s.ClientID,
 		"connectionType": "websocket",
 		"id":             s.MessageCount,
 	}
+	s.Unlock()
 
-	return s.send(message)
+	return s.send(ctx, message)
 }
 
-func (s *AvanzaSocket) sendHandshakeMessage(pushSubscriptionID string) error {
+func (s *AvanzaSocket) sendHandshakeMessage(ctx context.Context, pushSubscriptionID string) error {
 	message := map[string]interface{}{
 		"advice": map[string]interface{}{
 			"timeout":  60000,
@@ -179,14 +240,13 @@ func (s *AvanzaSocket) sendHandshakeMessage(pushSubscriptionID string) error {
 		"version":                  "1.0",
 	}
 
-	return s.send(message)
+	return s.send(ctx, message)
 }
 
-func (s *AvanzaSocket) socketSubscribe(subscriptionString string, callback func(string, map[string]interface{})) error {
+func (s *AvanzaSocket) socketSubscribe(ctx context.Context, subscriptionString string, callback func(string, map[string]interface{})) error {
 	s.Lock()
-	defer s.Unlock()
-
 	if _, ok := s.Subscriptions[subscriptionString]; ok {
+		s.Unlock()
 		return errors.New("subscription already exists")
 	}
 
@@ -197,26 +257,55 @@ func (s *AvanzaSocket) socketSubscribe(subscriptionString string, callback func(
 		Callback: callback,
 		ClientID: "",
 	}
+	s.Unlock()
+
+	return s.sendSubscribeMessage(ctx, subscriptionString)
+}
 
+func (s *AvanzaSocket) sendSubscribeMessage(ctx context.Context, subscriptionString string) error {
+	s.Lock()
 	message := map[string]interface{}{
 		"channel":      "/meta/subscribe",
 		"clientId":     s.ClientID,
 		"subscription": subscriptionString,
 	}
+	s.Unlock()
 
-	return s.send(message)
+	return s.send(ctx, message)
 }
 
-func (s *AvanzaSocket) handleDisconnectMessage() error {
+// Rehandshake performs a new handshake using pushSubscriptionID, which is
+// needed after the REST session has been renewed. Existing subscriptions are
+// resubscribed once the connection is re-established.
+func (s *AvanzaSocket) Rehandshake(ctx context.Context, pushSubscriptionID string) error {
+	s.Lock()
+	s.PushSubscriptionID = pushSubscriptionID
+	s.ClientID = ""
+	s.Connected = false
+	s.Unlock()
+
+	return s.sendHandshakeMessage(ctx, pushSubscriptionID)
+}
+
+// pushSubscriptionID returns the push subscription ID of the current session.
+func (s *AvanzaSocket) pushSubscriptionID() string {
+	s.Lock()
+	defer s.Unlock()
+	return s.PushSubscriptionID
+}
+
+func (s *AvanzaSocket) handleDisconnectMessage(ctx context.Context) error {
 	// TODO: log disconnect message
-	return s.sendHandshakeMessage(s.PushSubscriptionID)
+	return s.sendHandshakeMessage(ctx, s.pushSubscriptionID())
 }
 
-func (s *AvanzaSocket) handleHandshakeMessage(msg map[string]interface{}) error {
+func (s *AvanzaSocket) handleHandshakeMessage(ctx context.Context, msg map[string]interface{}) error {
 	successful, _ := msg["successful"].(bool)
 	if successful {
+		s.Lock()
 		s.ClientID, _ = msg["clientId"].(string)
-		err := s.sendConnectMessage()
+		s.Unlock()
+		err := s.sendConnectMessage(ctx)
 		if err != nil {
 			return err
 		}
@@ -224,7 +313,7 @@ func (s *AvanzaSocket) handleHandshakeMessage(msg map[string]interface{}) error
 		advice, _ := msg["advice"].(map[string]interface{})
 		reconnect, _ := advice["reconnect"].(string)
 		if reconnect == "handshake" {
-			err := s.sendHandshakeMessage(s.PushSubscriptionID)
+			err := s.sendHandshakeMessage(ctx, s.pushSubscriptionID())
 			if err != nil {
 				return err
 			}
@@ -234,27 +323,31 @@ func (s *AvanzaSocket) handleHandshakeMessage(msg map[string]interface{}) error
 	return nil
 }
 
-func (s *AvanzaSocket) handleConnectMessage(msg map[string]interface{}) error {
+func (s *AvanzaSocket) handleConnectMessage(ctx context.Context, msg map[string]interface{}) error {
 	successful, _ := msg["successful"].(bool)
 	advice, _ := msg["advice"].(map[string]interface{})
-	reconnect := advice["reconnect"].(string) == "retry"
+	reconnect, _ := advice["reconnect"].(string)
 	interval, _ := advice["interval"].(float64)
 
-	if successful && (advice == nil || reconnect && interval >= 0) {
-		err := s.sendConnectMessage()
+	if successful && (advice == nil || reconnect == "retry" && interval >= 0) {
+		err := s.sendConnectMessage(ctx)
 		if err != nil {
 			return err
 		}
 
-		if !s.Connected {
-			s.Connected = true
-			err := s.resubscribeExistingSubscriptions()
+		s.Lock()
+		connected := s.Connected
+		s.Connected = true
+		s.Unlock()
+
+		if !connected {
+			err := s.resubscribeExistingSubscriptions(ctx)
 			if err != nil {
 				return err
 			}
 		}
-	} else if s.ClientID != "" {
-		err := s.sendConnectMessage()
+	} else if s.hasClientID() {
+		err := s.sendConnectMessage(ctx)
 		if err != nil {
 			return err
 		}
@@ -263,13 +356,27 @@ func (s *AvanzaSocket) handleConnectMessage(msg map[string]interface{}) error {
 	return nil
 }
 
-func (s *AvanzaSocket) resubscribeExistingSubscriptions() error {
+// hasClientID reports whether a handshake has assigned a client ID.
+func (s *AvanzaSocket) hasClientID() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.ClientID != ""
+}
+
+func (s *AvanzaSocket) resubscribeExistingSubscriptions(ctx context.Context) error {
+	s.Lock()
+	var pending []string
 	for key, value := range s.Subscriptions {
 		if value.ClientID != s.ClientID {
-			err := s.socketSubscribe(key, value.Callback)
-			if err != nil {
-				return err
-			}
+			pending = append(pending, key)
+		}
+	}
+	s.Unlock()
+
+	for _, key := range pending {
+		err := s.sendSubscribeMessage(ctx, key)
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -2,11 +2,19 @@ package internal_test
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/JMrtzsn/govanza/internal"
 )
@@ -23,12 +31,14 @@ func TestNewAvanzaSocket(t *testing.T) {
 	// Create a logger that writes to the buffer
 	logger := log.New(&logBuffer, "", log.LstdFlags)
 
-	socket, err := internal.NewAvanzaSocket(pushSubscriptionID, cookies, reconnectLimit, logger)
+	socket, err := internal.NewAvanzaSocket(context.Background(), pushSubscriptionID, cookies, reconnectLimit, logger)
+	if err != nil {
+		t.Skipf("Live push server unreachable: %v", err)
+	}
 	assert.NotNil(t, socket, "Expected non-nil socket")
-	assert.NoError(t, err, "Unexpected error")
 
 	go func() {
-		_ = socket.Listen()
+		_ = socket.Listen(context.Background())
 	}()
 
 	time.Sleep(2 * time.Second)
@@ -47,3 +57,150 @@ func TestNewAvanzaSocket(t *testing.T) {
 }
 
 // TODO add tests for different messages (should connect to live server)
+
+func TestListenCancellation(t *testing.T) {
+	handshake := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, message, err := conn.ReadMessage()
+		if err == nil {
+			handshake <- message
+		}
+		// Keep the connection open without answering until the client leaves
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	socket, err := internal.DialAvanzaSocket(context.Background(), url, "12345", "", 5, log.New(io.Discard, "", 0))
+	require.NoError(t, err)
+	defer socket.Close()
+
+	t.Run("Assert that the handshake is sent to the given url", func(t *testing.T) {
+		select {
+		case message := <-handshake:
+			assert.Contains(t, string(message), "/meta/handshake")
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected a handshake message")
+		}
+	})
+
+	t.Run("Assert that Listen returns when ctx is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- socket.Listen(ctx)
+		}()
+
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected Listen to return after cancellation")
+		}
+	})
+}
+
+// cometdServer answers handshakes with a new client ID and the first connect
+// of every client ID, and reports the client IDs that have connected.
+func cometdServer(connected chan<- string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		handshakes := 0
+		seen := make(map[string]bool)
+		for {
+			var messages []map[string]interface{}
+			if err := conn.ReadJSON(&messages); err != nil {
+				return
+			}
+
+			for _, message := range messages {
+				var reply map[string]interface{}
+				switch message["channel"] {
+				case "/meta/handshake":
+					handshakes++
+					reply = map[string]interface{}{"channel": "/meta/handshake", "successful": true, "clientId": fmt.Sprintf("client-%d", handshakes)}
+				case "/meta/connect":
+					clientID, _ := message["clientId"].(string)
+					if seen[clientID] {
+						continue
+					}
+					seen[clientID] = true
+					connected <- clientID
+					reply = map[string]interface{}{"channel": "/meta/connect", "successful": true, "advice": map[string]interface{}{"reconnect": "retry", "interval": 0}}
+				case "/meta/subscribe":
+					reply = map[string]interface{}{"channel": "/meta/subscribe", "successful": true, "subscription": message["subscription"]}
+				default:
+					continue
+				}
+
+				if err := conn.WriteJSON([]interface{}{reply}); err != nil {
+					return
+				}
+			}
+		}
+	}))
+}
+
+func TestRehandshakeWhileListening(t *testing.T) {
+	connected := make(chan string, 16)
+	server := cometdServer(connected)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	socket, err := internal.DialAvanzaSocket(context.Background(), url, "push-1", "", 5, log.New(io.Discard, "", 0))
+	require.NoError(t, err)
+	defer socket.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- socket.Listen(ctx)
+	}()
+
+	t.Run("Assert that re-handshaking while listening reconnects without races", func(t *testing.T) {
+		const renewals = 3
+		for i := 0; i < renewals; i++ {
+			require.NoError(t, socket.SubscribeToID(ctx, "quotes", fmt.Sprint(i), func(string, map[string]interface{}) {}))
+			require.NoError(t, socket.Rehandshake(ctx, fmt.Sprintf("push-%d", i+2)))
+		}
+
+		// Every handshake, the initial one included, leads to a new connect
+		for i := 0; i <= renewals; i++ {
+			select {
+			case <-connected:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected the socket to connect after every handshake")
+			}
+		}
+
+		assert.Eventually(t, func() bool {
+			socket.Lock()
+			defer socket.Unlock()
+			return socket.Connected
+		}, 5*time.Second, 10*time.Millisecond)
+
+		socket.Lock()
+		defer socket.Unlock()
+		assert.Equal(t, "push-4", socket.PushSubscriptionID)
+	})
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,184 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// MonthlySaving is a recurring transfer that buys funds every month.
+type MonthlySaving struct {
+	ID                string             `json:"id"`
+	Account           AccountRef         `json:"account"`
+	Amount            float64            `json:"amount"`
+	TransferDay       int                `json:"transferDay"`
+	Status            string             `json:"status"`
+	Paused            bool               `json:"paused"`
+	Comment           string             `json:"comment"`
+	ExternalAccount   ExternalAccount    `json:"externalAccount"`
+	FundDistributions []FundDistribution `json:"fundDistributions"`
+}
+
+// ExternalAccount is the bank account a monthly saving withdraws from.
+type ExternalAccount struct {
+	ID             string `json:"id"`
+	AccountNumber  string `json:"accountNumber"`
+	ClearingNumber string `json:"clearingNumber"`
+	BankName       string `json:"bankName"`
+}
+
+// FundDistribution is the share of a monthly saving that buys one fund.
+type FundDistribution struct {
+	OrderbookID string  `json:"orderbookId"`
+	Name        string  `json:"name,omitempty"`
+	Percent     float64 `json:"percent"`
+	Amount      float64 `json:"amount,omitempty"`
+}
+
+// monthlySavingsResponse is returned by internal.MonthlySavingsPath.
+type monthlySavingsResponse struct {
+	MonthlySavings []MonthlySaving `json:"monthlySavings"`
+}
+
+// GetMonthlySavings returns the monthly savings of the account with
+// accountID, or of all accounts if accountID is empty.
+func (avanza *Avanza) GetMonthlySavings(ctx context.Context, accountID string) ([]MonthlySaving, error) {
+	path, err := internal.MonthlySavingsPath.Build(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response monthlySavingsResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.MonthlySavings, nil
+}
+
+// MonthlySavingRequest describes a monthly saving to create.
+type MonthlySavingRequest struct {
+	AccountID         string
+	Amount            float64
+	TransferDay       int    // Day of the month, 1 to 28
+	ExternalAccountID string // The bank account to withdraw from
+	Comment           string
+	FundDistributions []FundDistribution // Percentages must sum to 100
+}
+
+// Validate checks the request before it is sent.
+func (r MonthlySavingRequest) Validate() error {
+	switch {
+	case r.AccountID == "":
+		return fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case r.ExternalAccountID == "":
+		return fmt.Errorf("%w: external account id", ErrInvalidArgument)
+	case r.Amount <= 0 || math.IsNaN(r.Amount) || math.IsInf(r.Amount, 0):
+		return fmt.Errorf("%w: amount %v must be positive", ErrInvalidArgument, r.Amount)
+	case r.TransferDay < 1 || r.TransferDay > 28:
+		return fmt.Errorf("%w: transfer day %d must be between 1 and 28", ErrInvalidArgument, r.TransferDay)
+	case len(r.FundDistributions) == 0:
+		return fmt.Errorf("%w: fund distributions are required", ErrInvalidArgument)
+	}
+
+	seen := make(map[string]bool, len(r.FundDistributions))
+	var total float64
+	for _, distribution := range r.FundDistributions {
+		switch {
+		case distribution.OrderbookID == "":
+			return fmt.Errorf("%w: fund distribution orderbook id", ErrInvalidArgument)
+		case seen[distribution.OrderbookID]:
+			return fmt.Errorf("%w: fund %s is distributed more than once", ErrInvalidArgument, distribution.OrderbookID)
+		case distribution.Percent <= 0 || math.IsNaN(distribution.Percent):
+			return fmt.Errorf("%w: fund %s percent %v must be positive", ErrInvalidArgument, distribution.OrderbookID, distribution.Percent)
+		}
+		seen[distribution.OrderbookID] = true
+		total += distribution.Percent
+	}
+
+	if math.Abs(total-100) > 1e-9 {
+		return fmt.Errorf("%w: fund distribution percentages sum to %v, not 100", ErrInvalidArgument, total)
+	}
+	return nil
+}
+
+// createMonthlySavingRequest is the body sent to internal.MonthlySavingsCreatePath.
+type createMonthlySavingRequest struct {
+	Amount                     float64            `json:"amount"`
+	AutogiroTransferDayOfMonth int                `json:"autogiroTransferDayOfMonth"`
+	Comment                    string             `json:"comment"`
+	ExternalBankAccountID      string             `json:"externalBankAccountId"`
+	FundDistributions          map[string]float64 `json:"fundDistributions"`
+}
+
+// createMonthlySavingResponse is returned by internal.MonthlySavingsCreatePath.
+type createMonthlySavingResponse struct {
+	MonthlySavingID string `json:"monthlySavingId"`
+	Status          string `json:"status"`
+}
+
+// CreateMonthlySaving creates a monthly saving and returns its ID.
+func (avanza *Avanza) CreateMonthlySaving(ctx context.Context, request MonthlySavingRequest) (string, error) {
+	if err := request.Validate(); err != nil {
+		return "", err
+	}
+
+	path, err := internal.MonthlySavingsCreatePath.Build(request.AccountID)
+	if err != nil {
+		return "", err
+	}
+
+	data := createMonthlySavingRequest{
+		Amount:                     request.Amount,
+		AutogiroTransferDayOfMonth: request.TransferDay,
+		Comment:                    request.Comment,
+		ExternalBankAccountID:      request.ExternalAccountID,
+		FundDistributions:          make(map[string]float64, len(request.FundDistributions)),
+	}
+	for _, distribution := range request.FundDistributions {
+		data.FundDistributions[distribution.OrderbookID] = distribution.Percent
+	}
+
+	var response createMonthlySavingResponse
+	err = avanza.requestJSON(ctx, http.MethodPost, path, data, &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.MonthlySavingID, nil
+}
+
+// PauseMonthlySaving pauses the monthly saving with monthlySavingID until it is resumed.
+func (avanza *Avanza) PauseMonthlySaving(ctx context.Context, accountID, monthlySavingID string) error {
+	return avanza.changeMonthlySaving(ctx, http.MethodPut, internal.MonthlySavingsPausePath, accountID, monthlySavingID)
+}
+
+// ResumeMonthlySaving resumes a paused monthly saving.
+func (avanza *Avanza) ResumeMonthlySaving(ctx context.Context, accountID, monthlySavingID string) error {
+	return avanza.changeMonthlySaving(ctx, http.MethodPut, internal.MonthlySavingsResumePath, accountID, monthlySavingID)
+}
+
+// RemoveMonthlySaving removes the monthly saving with monthlySavingID.
+func (avanza *Avanza) RemoveMonthlySaving(ctx context.Context, accountID, monthlySavingID string) error {
+	return avanza.changeMonthlySaving(ctx, http.MethodDelete, internal.MonthlySavingsRemovePath, accountID, monthlySavingID)
+}
+
+func (avanza *Avanza) changeMonthlySaving(ctx context.Context, method string, route internal.Route, accountID, monthlySavingID string) error {
+	switch {
+	case accountID == "":
+		return fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case monthlySavingID == "":
+		return fmt.Errorf("%w: monthly saving id", ErrInvalidArgument)
+	}
+
+	path, err := route.Build(accountID, monthlySavingID)
+	if err != nil {
+		return err
+	}
+
+	return avanza.requestJSON(ctx, method, path, nil, nil)
+}
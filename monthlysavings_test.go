@@ -0,0 +1,89 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlySavings(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/transfer/monthly-savings/1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"monthlySavings": []map[string]interface{}{
+			{"id": "ms1", "amount": 1000, "transferDay": 25, "fundDistributions": []map[string]interface{}{
+				{"orderbookId": "878733", "percent": 100},
+			}},
+		}})
+	})
+	mux.HandleFunc("/_api/transfer/monthly-savings/1", func(w http.ResponseWriter, r *http.Request) {
+		var body createMonthlySavingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, 25, body.AutogiroTransferDayOfMonth)
+		assert.Equal(t, map[string]float64{"878733": 60, "325406": 40}, body.FundDistributions)
+		writeJSON(w, map[string]string{"monthlySavingId": "ms2", "status": "SUCCESS"})
+	})
+	mux.HandleFunc("/_api/transfer/monthly-savings/1/ms1/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	})
+	for _, action := range []string{"pause", "resume"} {
+		mux.HandleFunc("/_api/transfer/monthly-savings/1/ms1/"+action, func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		})
+	}
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	request := MonthlySavingRequest{
+		AccountID:         "1",
+		Amount:            1000,
+		TransferDay:       25,
+		ExternalAccountID: "bank",
+		FundDistributions: []FundDistribution{{OrderbookID: "878733", Percent: 60}, {OrderbookID: "325406", Percent: 40}},
+	}
+
+	t.Run("Assert that monthly savings are listed", func(t *testing.T) {
+		savings, err := avanza.GetMonthlySavings(ctx, "1")
+		require.NoError(t, err)
+		require.Len(t, savings, 1)
+		assert.Equal(t, "ms1", savings[0].ID)
+		assert.Equal(t, 100.0, savings[0].FundDistributions[0].Percent)
+	})
+
+	t.Run("Assert that a monthly saving is created", func(t *testing.T) {
+		id, err := avanza.CreateMonthlySaving(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, "ms2", id)
+	})
+
+	t.Run("Assert that fund distributions must sum to 100", func(t *testing.T) {
+		invalid := request
+		invalid.FundDistributions = []FundDistribution{{OrderbookID: "878733", Percent: 60}, {OrderbookID: "325406", Percent: 30}}
+		_, err := avanza.CreateMonthlySaving(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		invalid.FundDistributions = []FundDistribution{{OrderbookID: "878733", Percent: 50}, {OrderbookID: "878733", Percent: 50}}
+		_, err = avanza.CreateMonthlySaving(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		invalid = request
+		invalid.TransferDay = 31
+		_, err = avanza.CreateMonthlySaving(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("Assert that monthly savings are paused, resumed and removed", func(t *testing.T) {
+		require.NoError(t, avanza.PauseMonthlySaving(ctx, "1", "ms1"))
+		require.NoError(t, avanza.ResumeMonthlySaving(ctx, "1", "ms1"))
+		require.NoError(t, avanza.RemoveMonthlySaving(ctx, "1", "ms1"))
+		assert.Equal(t, []string{
+			"PUT /_api/transfer/monthly-savings/1/ms1/pause",
+			"PUT /_api/transfer/monthly-savings/1/ms1/resume",
+			"DELETE /_api/transfer/monthly-savings/1/ms1/",
+		}, requests)
+	})
+}
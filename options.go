@@ -0,0 +1,134 @@
+package govanza
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SocketMode controls when the push socket is opened.
+type SocketMode int
+
+const (
+	SocketEager    SocketMode = iota // Open the socket in NewAvanza
+	SocketLazy                       // Open the socket on the first call to PushSocket
+	SocketDisabled                   // Never open the socket
+)
+
+// Option configures an Avanza client in NewAvanza.
+type Option func(*Avanza) error
+
+// WithHTTPClient uses client for all REST calls. The client is copied and
+// given a cookie jar if it does not have one.
+func WithHTTPClient(client *http.Client) Option {
+	return func(avanza *Avanza) error {
+		if client == nil {
+			return fmt.Errorf("http client must not be nil")
+		}
+		copied := *client
+		avanza.Session = &copied
+		return nil
+	}
+}
+
+// WithTransport uses transport for all REST calls. It is applied after all
+// other options, so it also applies to a client given with WithHTTPClient
+// regardless of the order of the options.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(avanza *Avanza) error {
+		if transport == nil {
+			return fmt.Errorf("transport must not be nil")
+		}
+		avanza.transport = transport
+		return nil
+	}
+}
+
+// WithBaseURL sends REST calls to baseURL instead of BaseURL, e.g. a local
+// stand-in. The push socket connects to the same host.
+func WithBaseURL(baseURL string) Option {
+	return func(avanza *Avanza) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid base url %q: %w", baseURL, err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid base url %q: scheme and host are required", baseURL)
+		}
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+		}
+		avanza.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// WithAuthenticationTimeout sets how many minutes the session may be
+// inactive, between MinInactiveMinutes and MaxInactiveMinutes.
+func WithAuthenticationTimeout(minutes int) Option {
+	return func(avanza *Avanza) error {
+		if minutes < MinInactiveMinutes || minutes > MaxInactiveMinutes {
+			return fmt.Errorf("authentication timeout %d must be between %d and %d minutes", minutes, MinInactiveMinutes, MaxInactiveMinutes)
+		}
+		avanza.AuthenticationTimeout = minutes
+		return nil
+	}
+}
+
+// WithLogger logs through logger, which is also handed to the push socket.
+func WithLogger(logger *log.Logger) Option {
+	return func(avanza *Avanza) error {
+		if logger == nil {
+			return fmt.Errorf("logger must not be nil")
+		}
+		avanza.logger = logger
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header on all REST calls.
+func WithUserAgent(userAgent string) Option {
+	return func(avanza *Avanza) error {
+		avanza.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithSocketMode controls when the push socket is opened, SocketEager by default.
+func WithSocketMode(mode SocketMode) Option {
+	return func(avanza *Avanza) error {
+		if mode < SocketEager || mode > SocketDisabled {
+			return fmt.Errorf("unknown socket mode %d", mode)
+		}
+		avanza.socketMode = mode
+		return nil
+	}
+}
+
+// WithRetryPolicy retries failed requests according to policy, see DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(avanza *Avanza) error {
+		if policy.MaxRetries < 0 || policy.InitialBackoff < 0 || policy.MaxBackoff < 0 || policy.MaxElapsed < 0 {
+			return fmt.Errorf("retry policy values must not be negative")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return fmt.Errorf("retry jitter %v must be between 0 and 1", policy.Jitter)
+		}
+		avanza.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter throttles REST calls and push socket messages with limiter,
+// which may be shared with other clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(avanza *Avanza) error {
+		if limiter == nil {
+			return fmt.Errorf("rate limiter must not be nil")
+		}
+		avanza.rateLimiter = limiter
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+func TestNewAvanzaOptions(t *testing.T) {
+	credentials := Credentials{Username: "user", Password: "pass", TOTPSecret: "JBSWY3DPEHPK3PXP"}
+
+	var logins int32
+	mux := loginServer(&logins)
+	mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "govanza-test", r.Header.Get("User-Agent"))
+	})
+	handshakes := make(chan map[string]interface{}, 1)
+	mux.HandleFunc("/_push/cometd", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var messages []map[string]interface{}
+		if err := conn.ReadJSON(&messages); err == nil && len(messages) > 0 {
+			handshakes <- messages[0]
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("Assert that options configure the client", func(t *testing.T) {
+		client := &http.Client{}
+		avanza, err := NewAvanza(context.Background(), credentials,
+			WithHTTPClient(client),
+			WithBaseURL(server.URL),
+			WithAuthenticationTimeout(MinInactiveMinutes),
+			WithUserAgent("govanza-test"),
+			WithSocketMode(SocketDisabled),
+		)
+		require.NoError(t, err)
+		defer avanza.Close()
+
+		assert.Equal(t, MinInactiveMinutes, avanza.AuthenticationTimeout)
+		assert.NotNil(t, avanza.Session.Jar)
+		assert.Nil(t, client.Jar, "Expected the supplied client to be left untouched")
+
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		_, err = avanza.PushSocket(context.Background())
+		assert.ErrorIs(t, err, ErrSocketDisabled)
+	})
+
+	t.Run("Assert that a lazy socket is not opened in NewAvanza", func(t *testing.T) {
+		avanza, err := NewAvanza(context.Background(), credentials, WithBaseURL(server.URL), WithSocketMode(SocketLazy))
+		require.NoError(t, err)
+		defer avanza.Close()
+
+		assert.Nil(t, avanza.Socket)
+	})
+
+	t.Run("Assert that a transport is kept regardless of the order of options", func(t *testing.T) {
+		transport := &http.Transport{}
+		orders := [][]Option{
+			{WithTransport(transport), WithHTTPClient(&http.Client{})},
+			{WithHTTPClient(&http.Client{}), WithTransport(transport)},
+		}
+
+		for _, options := range orders {
+			avanza, err := newAvanza(credentials, options...)
+			require.NoError(t, err)
+			assert.Same(t, transport, avanza.Session.Transport)
+		}
+	})
+
+	t.Run("Assert that the push socket connects to the host of the base url", func(t *testing.T) {
+		avanza, err := NewAvanza(context.Background(), credentials, WithBaseURL(server.URL))
+		require.NoError(t, err)
+		defer avanza.Close()
+
+		select {
+		case handshake := <-handshakes:
+			assert.Equal(t, "/meta/handshake", handshake["channel"])
+			ext, _ := handshake["ext"].(map[string]interface{})
+			assert.Equal(t, avanza.CurrentSession().PushSubscriptionID, ext["subscriptionId"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected a handshake on the stand-in push socket")
+		}
+	})
+
+	t.Run("Assert that the default push socket is Avanza's", func(t *testing.T) {
+		avanza, err := newAvanza(credentials)
+		require.NoError(t, err)
+		assert.Equal(t, "wss://www.avanza.se/_push/cometd", avanza.socketURL())
+	})
+
+	t.Run("Assert that invalid options are rejected", func(t *testing.T) {
+		invalid := []Option{
+			WithAuthenticationTimeout(MinInactiveMinutes - 1),
+			WithAuthenticationTimeout(MaxInactiveMinutes + 1),
+			WithBaseURL("localhost"),
+			WithBaseURL("ftp://localhost"),
+			WithHTTPClient(nil),
+			WithSocketMode(SocketMode(42)),
+		}
+
+		for _, option := range invalid {
+			_, err := NewAvanza(context.Background(), credentials, option)
+			assert.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,582 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// OrderStatus is the state of an order.
+type OrderStatus int
+
+const (
+	OrderStatusUnknown OrderStatus = iota
+	OrderStatusPending
+	OrderStatusActive
+	OrderStatusPartiallyFilled
+	OrderStatusFilled
+	OrderStatusCancelled
+	OrderStatusRejected
+	OrderStatusExpired
+)
+
+func (s OrderStatus) String() string {
+	switch s {
+	case OrderStatusPending:
+		return "PENDING"
+	case OrderStatusActive:
+		return "ACTIVE"
+	case OrderStatusPartiallyFilled:
+		return "PARTIALLY_FILLED"
+	case OrderStatusFilled:
+		return "FILLED"
+	case OrderStatusCancelled:
+		return "CANCELLED"
+	case OrderStatusRejected:
+		return "REJECTED"
+	case OrderStatusExpired:
+		return "EXPIRED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// parseOrderStatus maps the raw statuses used by the different order
+// endpoints, unrecognised statuses become OrderStatusUnknown.
+func parseOrderStatus(s string) OrderStatus {
+	switch upper(s) {
+	case "PENDING", "QUEUED", "SUBMITTED":
+		return OrderStatusPending
+	case "ACTIVE", "ACTIVE_MARKET", "SUCCESS":
+		return OrderStatusActive
+	case "PARTIALLY_FILLED", "PARTIALLY_EXECUTED":
+		return OrderStatusPartiallyFilled
+	case "FILLED", "EXECUTED", "DONE":
+		return OrderStatusFilled
+	case "CANCELLED", "DELETED":
+		return OrderStatusCancelled
+	case "REJECTED", "ERROR", "FAILED":
+		return OrderStatusRejected
+	case "EXPIRED":
+		return OrderStatusExpired
+	default:
+		return OrderStatusUnknown
+	}
+}
+
+func (s OrderStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = parseOrderStatus(raw)
+	return nil
+}
+
+// AccountRef identifies the account an order or deal belongs to.
+type AccountRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// OrderbookRef identifies the orderbook an order or deal was placed in.
+type OrderbookRef struct {
+	ID             string                  `json:"id"`
+	Name           string                  `json:"name"`
+	InstrumentType internal.InstrumentType `json:"type"`
+	Currency       string                  `json:"currency"`
+	MarketPlace    string                  `json:"marketPlace"`
+}
+
+// Order is an order placed today.
+type Order struct {
+	OrderID           string             `json:"orderId"`
+	Account           AccountRef         `json:"account"`
+	Orderbook         OrderbookRef       `json:"orderbook"`
+	Side              internal.OrderType `json:"type"`
+	Status            OrderStatus        `json:"rawStatus"`
+	StatusDescription string             `json:"statusDescription"`
+	Price             float64            `json:"price"`
+	Volume            float64            `json:"volume"`
+	OpenVolume        *float64           `json:"openVolume"` // Nil if Avanza did not report it
+	Sum               float64            `json:"sum"`
+	ValidUntil        Date               `json:"validUntil"`
+	Created           Timestamp          `json:"orderDateTime"`
+	Modifiable        bool               `json:"modifyAllowed"`
+	Deletable         bool               `json:"deletable"`
+}
+
+// RemainingVolume returns the volume that has not been filled yet.
+func (o Order) RemainingVolume() float64 {
+	switch {
+	case o.OpenVolume != nil:
+		return *o.OpenVolume
+	case o.Status == OrderStatusFilled:
+		return 0
+	default:
+		return o.Volume
+	}
+}
+
+// FilledVolume returns the volume that has been filled.
+func (o Order) FilledVolume() float64 {
+	return o.Volume - o.RemainingVolume()
+}
+
+// Deal is an execution of an order.
+type Deal struct {
+	DealID    string             `json:"dealId"`
+	OrderID   string             `json:"orderId"`
+	Account   AccountRef         `json:"account"`
+	Orderbook OrderbookRef       `json:"orderbook"`
+	Side      internal.OrderType `json:"type"`
+	Price     float64            `json:"price"`
+	Volume    float64            `json:"volume"`
+	Sum       float64            `json:"sum"`
+	DealTime  Timestamp          `json:"dealTime"`
+}
+
+// Orders is a list of orders with helpers for filtering them.
+type Orders []Order
+
+// ForAccount returns the orders in the account with accountID.
+func (o Orders) ForAccount(accountID string) Orders {
+	var filtered Orders
+	for _, order := range o {
+		if order.Account.ID == accountID {
+			filtered = append(filtered, order)
+		}
+	}
+	return filtered
+}
+
+// ForOrderbook returns the orders in the orderbook with orderbookID.
+func (o Orders) ForOrderbook(orderbookID string) Orders {
+	var filtered Orders
+	for _, order := range o {
+		if order.Orderbook.ID == orderbookID {
+			filtered = append(filtered, order)
+		}
+	}
+	return filtered
+}
+
+// Deals is a list of deals with helpers for filtering them.
+type Deals []Deal
+
+// ForAccount returns the deals in the account with accountID.
+func (d Deals) ForAccount(accountID string) Deals {
+	var filtered Deals
+	for _, deal := range d {
+		if deal.Account.ID == accountID {
+			filtered = append(filtered, deal)
+		}
+	}
+	return filtered
+}
+
+// ForOrderbook returns the deals in the orderbook with orderbookID.
+func (d Deals) ForOrderbook(orderbookID string) Deals {
+	var filtered Deals
+	for _, deal := range d {
+		if deal.Orderbook.ID == orderbookID {
+			filtered = append(filtered, deal)
+		}
+	}
+	return filtered
+}
+
+// DealsAndOrders holds today's orders and deals.
+type DealsAndOrders struct {
+	Orders Orders `json:"orders"`
+	Deals  Deals  `json:"deals"`
+}
+
+// GetDealsAndOrders returns today's orders and executed deals in all accounts.
+func (avanza *Avanza) GetDealsAndOrders(ctx context.Context) (*DealsAndOrders, error) {
+	path, err := internal.DealsAndOrdersPath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var dealsAndOrders DealsAndOrders
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &dealsAndOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dealsAndOrders, nil
+}
+
+// OrderCondition controls how an order is matched.
+type OrderCondition int
+
+const (
+	ConditionNormal      OrderCondition = iota // Stays in the orderbook until filled or no longer valid
+	ConditionFillOrKill                        // Fills the whole volume immediately or is cancelled
+	ConditionFillAndKill                       // Fills what it can immediately, the rest is cancelled
+)
+
+func (c OrderCondition) String() string {
+	switch c {
+	case ConditionNormal:
+		return "NORMAL"
+	case ConditionFillOrKill:
+		return "FILL_OR_KILL"
+	case ConditionFillAndKill:
+		return "FILL_AND_KILL"
+	}
+	return ""
+}
+
+func (c OrderCondition) MarshalJSON() ([]byte, error) {
+	if c.String() == "" {
+		return nil, fmt.Errorf("unknown order condition %d", int(c))
+	}
+	return json.Marshal(c.String())
+}
+
+// OrderRequest describes a limit order to place.
+type OrderRequest struct {
+	AccountID   string
+	OrderbookID string
+	Side        internal.OrderType
+	Price       float64
+	Volume      float64
+	ValidUntil  Date           // Defaults to today
+	Condition   OrderCondition // Defaults to ConditionNormal
+	TickSize    float64        // If set, Price must be a multiple of it
+}
+
+// Validate checks the request before it is sent.
+func (r OrderRequest) Validate() error {
+	switch {
+	case r.AccountID == "":
+		return fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case r.OrderbookID == "":
+		return fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	case r.Side.String() == "":
+		return fmt.Errorf("%w: unknown side %d", ErrInvalidArgument, int(r.Side))
+	case r.Condition.String() == "":
+		return fmt.Errorf("%w: unknown condition %d", ErrInvalidArgument, int(r.Condition))
+	}
+
+	if err := validatePriceAndVolume(r.Price, r.Volume, r.TickSize); err != nil {
+		return err
+	}
+	return validateValidUntil(r.ValidUntil, time.Now())
+}
+
+// validatePriceAndVolume checks that price and volume are positive and that
+// price is on the tick size, if one is given.
+func validatePriceAndVolume(price, volume, tickSize float64) error {
+	switch {
+	case price <= 0 || math.IsNaN(price) || math.IsInf(price, 0):
+		return fmt.Errorf("%w: price %v must be positive", ErrInvalidArgument, price)
+	case volume <= 0 || math.IsNaN(volume) || math.IsInf(volume, 0):
+		return fmt.Errorf("%w: volume %v must be positive", ErrInvalidArgument, volume)
+	case tickSize < 0:
+		return fmt.Errorf("%w: tick size %v must not be negative", ErrInvalidArgument, tickSize)
+	}
+
+	if tickSize > 0 {
+		ticks := price / tickSize
+		if math.Abs(ticks-math.Round(ticks)) > 1e-9*math.Max(1, ticks) {
+			return fmt.Errorf("%w: price %v is not a multiple of the tick size %v", ErrInvalidArgument, price, tickSize)
+		}
+	}
+	return nil
+}
+
+// validateValidUntil checks that validUntil, if set, is not before today.
+func validateValidUntil(validUntil Date, now time.Time) error {
+	if !validUntil.IsZero() && validUntil.Before(NewDate(now).Time) {
+		return fmt.Errorf("%w: valid until %s is in the past", ErrInvalidArgument, validUntil)
+	}
+	return nil
+}
+
+// OrderResult is the outcome of placing or changing an order.
+type OrderResult struct {
+	OrderID string
+	Status  OrderStatus
+	Message string // Avanza's explanation if the order was rejected
+}
+
+// placeOrderRequest is the body sent to internal.OrderPlacePath.
+type placeOrderRequest struct {
+	AccountID   string             `json:"accountId"`
+	OrderbookID string             `json:"orderbookId"`
+	Side        internal.OrderType `json:"side"`
+	Price       float64            `json:"price"`
+	Volume      float64            `json:"volume"`
+	ValidUntil  Date               `json:"validUntil"`
+	Condition   OrderCondition     `json:"condition"`
+}
+
+// orderResponse is returned by the order endpoints.
+type orderResponse struct {
+	OrderRequestStatus string `json:"orderRequestStatus"`
+	Message            string `json:"message"`
+	OrderID            string `json:"orderId"`
+}
+
+// result converts the response, returning an error wrapping ErrOrderRejected
+// together with the result if Avanza rejected the request.
+func (r orderResponse) result() (*OrderResult, error) {
+	result := &OrderResult{
+		OrderID: r.OrderID,
+		Status:  parseOrderStatus(r.OrderRequestStatus),
+		Message: r.Message,
+	}
+
+	if result.Status == OrderStatusRejected {
+		return result, fmt.Errorf("%w: %s", ErrOrderRejected, r.Message)
+	}
+	return result, nil
+}
+
+// PlaceOrder places a limit order. If Avanza rejects the order the result is
+// returned together with an error wrapping ErrOrderRejected.
+func (avanza *Avanza) PlaceOrder(ctx context.Context, request OrderRequest) (*OrderResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	validUntil := request.ValidUntil
+	if validUntil.IsZero() {
+		validUntil = NewDate(time.Now())
+	}
+
+	path, err := internal.OrderPlacePath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	data := placeOrderRequest{
+		AccountID:   request.AccountID,
+		OrderbookID: request.OrderbookID,
+		Side:        request.Side,
+		Price:       request.Price,
+		Volume:      request.Volume,
+		ValidUntil:  validUntil,
+		Condition:   request.Condition,
+	}
+
+	var response orderResponse
+	err = avanza.requestJSON(ctx, http.MethodPost, path, data, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.result()
+}
+
+// orderGetResponse is returned by internal.OrderGetPath.
+type orderGetResponse struct {
+	Order     Order        `json:"order"`
+	Account   AccountRef   `json:"account"`
+	Orderbook OrderbookRef `json:"orderbook"`
+}
+
+// GetOrder returns an order placed in an orderbook of the given instrument type.
+func (avanza *Avanza) GetOrder(ctx context.Context, instrumentType internal.InstrumentType, accountID, orderID string) (*Order, error) {
+	switch {
+	case instrumentType == internal.Any:
+		return nil, fmt.Errorf("%w: instrument type is required", ErrInvalidArgument)
+	case accountID == "":
+		return nil, fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case orderID == "":
+		return nil, fmt.Errorf("%w: order id", ErrInvalidArgument)
+	}
+
+	path, err := internal.OrderGetPath.Build(instrumentType, accountID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response orderGetResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	order := response.Order
+	if order.OrderID == "" {
+		order.OrderID = orderID
+	}
+	if order.Account.ID == "" {
+		order.Account = response.Account
+	}
+	if order.Orderbook.ID == "" {
+		order.Orderbook = response.Orderbook
+	}
+	return &order, nil
+}
+
+// ModifyOrderRequest describes the new price, volume and validity of an order.
+type ModifyOrderRequest struct {
+	AccountID      string
+	OrderID        string
+	InstrumentType internal.InstrumentType
+	Price          float64
+	Volume         float64
+	ValidUntil     Date    // Defaults to the current validity of the order
+	TickSize       float64 // If set, Price must be a multiple of it
+}
+
+// Validate checks the request before it is sent.
+func (r ModifyOrderRequest) Validate() error {
+	switch {
+	case r.AccountID == "":
+		return fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case r.OrderID == "":
+		return fmt.Errorf("%w: order id", ErrInvalidArgument)
+	case r.InstrumentType == internal.Any:
+		return fmt.Errorf("%w: instrument type is required", ErrInvalidArgument)
+	}
+
+	if err := validatePriceAndVolume(r.Price, r.Volume, r.TickSize); err != nil {
+		return err
+	}
+	return validateValidUntil(r.ValidUntil, time.Now())
+}
+
+// modifyOrderRequest is the body sent to internal.OrderEditPath.
+type modifyOrderRequest struct {
+	AccountID  string  `json:"accountId"`
+	OrderID    string  `json:"orderId"`
+	Price      float64 `json:"price"`
+	Volume     float64 `json:"volume"`
+	ValidUntil Date    `json:"validUntil"`
+}
+
+// ModifyOrder changes the price, volume and validity of an open order. The
+// current validity is kept unless ValidUntil is set. If the order could not
+// be changed because it has already been filled the error wraps
+// ErrOrderFilled.
+func (avanza *Avanza) ModifyOrder(ctx context.Context, request ModifyOrderRequest) (*OrderResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Keep the validity of the order unless a new one is given
+	validUntil := request.ValidUntil
+	if validUntil.IsZero() {
+		order, err := avanza.GetOrder(ctx, request.InstrumentType, request.AccountID, request.OrderID)
+		if err != nil {
+			return nil, avanza.orderFailure(ctx, request.AccountID, request.OrderID, err)
+		}
+		if order.ValidUntil.IsZero() {
+			return nil, fmt.Errorf("%w: valid until of order %s is unknown", ErrInvalidArgument, request.OrderID)
+		}
+		validUntil = order.ValidUntil
+	}
+
+	path, err := internal.OrderEditPath.Build(request.InstrumentType, request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := modifyOrderRequest{
+		AccountID:  request.AccountID,
+		OrderID:    request.OrderID,
+		Price:      request.Price,
+		Volume:     request.Volume,
+		ValidUntil: validUntil,
+	}
+
+	var response orderResponse
+	err = avanza.requestJSON(ctx, http.MethodPut, path, data, &response)
+	if err != nil {
+		return nil, avanza.orderFailure(ctx, request.AccountID, request.OrderID, err)
+	}
+
+	result, err := response.result()
+	if err != nil {
+		return result, avanza.orderFailure(ctx, request.AccountID, request.OrderID, err)
+	}
+	return result, nil
+}
+
+// CancelOrder cancels an open order. If the order could not be cancelled
+// because it has already been filled the error wraps ErrOrderFilled.
+func (avanza *Avanza) CancelOrder(ctx context.Context, accountID, orderID string) (*OrderResult, error) {
+	switch {
+	case accountID == "":
+		return nil, fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case orderID == "":
+		return nil, fmt.Errorf("%w: order id", ErrInvalidArgument)
+	}
+
+	path, err := internal.OrderDeletePath.Build(accountID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response orderResponse
+	err = avanza.requestJSON(ctx, http.MethodDelete, path, nil, &response)
+	if err != nil {
+		return nil, avanza.orderFailure(ctx, accountID, orderID, err)
+	}
+
+	result, err := response.result()
+	if err != nil {
+		return result, avanza.orderFailure(ctx, accountID, orderID, err)
+	}
+
+	if result.OrderID == "" {
+		result.OrderID = orderID
+	}
+	if result.Status == OrderStatusActive {
+		// The request succeeded, so the order is gone
+		result.Status = OrderStatusCancelled
+	}
+	return result, nil
+}
+
+// orderFailure checks today's orders and deals to tell whether a failed
+// change to an order failed because the order has already been filled, in
+// which case the returned error also wraps ErrOrderFilled.
+func (avanza *Avanza) orderFailure(ctx context.Context, accountID, orderID string, err error) error {
+	if !errors.Is(err, ErrOrderRejected) && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	dealsAndOrders, lookupErr := avanza.GetDealsAndOrders(ctx)
+	if lookupErr != nil {
+		return err
+	}
+
+	listed := false
+	for _, order := range dealsAndOrders.Orders.ForAccount(accountID) {
+		if order.OrderID != orderID {
+			continue
+		}
+		if order.Status == OrderStatusFilled {
+			return fmt.Errorf("%w: %w", ErrOrderFilled, err)
+		}
+		listed = true
+	}
+
+	if !listed {
+		for _, deal := range dealsAndOrders.Deals.ForAccount(accountID) {
+			if deal.OrderID == orderID {
+				return fmt.Errorf("%w: %w", ErrOrderFilled, err)
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,214 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDealsAndOrders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/account/dealsandorders", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"orders": [
+				{"orderId": "o1", "account": {"id": "1", "name": "ISK"}, "orderbook": {"id": "5247", "type": "STOCK", "currency": "SEK"},
+				 "type": "BUY", "rawStatus": "ACTIVE", "price": 100, "volume": 10, "openVolume": 4,
+				 "validUntil": "2023-05-05", "orderDateTime": "2023-05-05T09:00:00.000+0200", "modifyAllowed": true},
+				{"orderId": "o2", "account": {"id": "2"}, "orderbook": {"id": "5361", "type": "STOCK"},
+				 "type": "SELL", "rawStatus": "SOMETHING_NEW", "price": 50, "volume": 5}
+			],
+			"deals": [
+				{"dealId": "d1", "account": {"id": "1"}, "orderbook": {"id": "5247"}, "type": "BUY",
+				 "price": 100, "volume": 6, "sum": 600, "dealTime": 1683270000000}
+			]
+		}`))
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	result, err := avanza.GetDealsAndOrders(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 2)
+	require.Len(t, result.Deals, 1)
+
+	t.Run("Assert that orders are decoded with typed status and volumes", func(t *testing.T) {
+		order := result.Orders[0]
+		assert.Equal(t, Buy, order.Side)
+		assert.Equal(t, OrderStatusActive, order.Status)
+		assert.Equal(t, InstrumentStock, order.Orderbook.InstrumentType)
+		assert.Equal(t, 4.0, order.RemainingVolume())
+		assert.Equal(t, 6.0, order.FilledVolume())
+		assert.Equal(t, "2023-05-05", order.ValidUntil.String())
+		assert.True(t, order.Created.Equal(time.Date(2023, 5, 5, 7, 0, 0, 0, time.UTC)))
+
+		assert.Equal(t, OrderStatusUnknown, result.Orders[1].Status)
+		assert.Equal(t, 5.0, result.Orders[1].RemainingVolume())
+	})
+
+	t.Run("Assert that deals are decoded", func(t *testing.T) {
+		deal := result.Deals[0]
+		assert.Equal(t, "d1", deal.DealID)
+		assert.Equal(t, 600.0, deal.Sum)
+		assert.Equal(t, Stockholm, deal.DealTime.Location())
+		assert.Equal(t, int64(1683270000000), deal.DealTime.UnixMilli())
+	})
+
+	t.Run("Assert that orders and deals can be filtered", func(t *testing.T) {
+		assert.Len(t, result.Orders.ForAccount("1"), 1)
+		assert.Len(t, result.Orders.ForOrderbook("5361"), 1)
+		assert.Empty(t, result.Orders.ForAccount("3"))
+		assert.Len(t, result.Deals.ForAccount("1"), 1)
+		assert.Empty(t, result.Deals.ForOrderbook("5361"))
+	})
+}
+
+func TestPlaceOrder(t *testing.T) {
+	valid := OrderRequest{
+		AccountID:   "1",
+		OrderbookID: "5247",
+		Side:        Sell,
+		Price:       100.5,
+		Volume:      10,
+		Condition:   ConditionFillOrKill,
+		TickSize:    0.1,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/trading-critical/rest/order/new", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "SELL", body["side"])
+		assert.Equal(t, "FILL_OR_KILL", body["condition"])
+		assert.Equal(t, NewDate(time.Now()).String(), body["validUntil"])
+
+		if body["volume"] == 10.0 {
+			writeJSON(w, map[string]string{"orderRequestStatus": "SUCCESS", "orderId": "o1"})
+			return
+		}
+		writeJSON(w, map[string]string{"orderRequestStatus": "ERROR", "message": "insufficient funds"})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	t.Run("Assert that a valid order is placed", func(t *testing.T) {
+		result, err := avanza.PlaceOrder(context.Background(), valid)
+		require.NoError(t, err)
+		assert.Equal(t, &OrderResult{OrderID: "o1", Status: OrderStatusActive}, result)
+	})
+
+	t.Run("Assert that a rejection is returned with its message", func(t *testing.T) {
+		request := valid
+		request.Volume = 1000
+		result, err := avanza.PlaceOrder(context.Background(), request)
+		assert.ErrorIs(t, err, ErrOrderRejected)
+		require.NotNil(t, result)
+		assert.Equal(t, OrderStatusRejected, result.Status)
+		assert.Equal(t, "insufficient funds", result.Message)
+	})
+
+	t.Run("Assert that invalid orders are rejected before they are sent", func(t *testing.T) {
+		invalid := []func(*OrderRequest){
+			func(r *OrderRequest) { r.AccountID = "" },
+			func(r *OrderRequest) { r.Volume = 0 },
+			func(r *OrderRequest) { r.Price = -1 },
+			func(r *OrderRequest) { r.Price = 100.55 },
+			func(r *OrderRequest) { r.ValidUntil = NewDate(time.Now().AddDate(0, 0, -1)) },
+			func(r *OrderRequest) { r.Condition = OrderCondition(9) },
+		}
+
+		for _, modify := range invalid {
+			request := valid
+			modify(&request)
+			_, err := avanza.PlaceOrder(context.Background(), request)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		}
+	})
+}
+
+func TestModifyAndCancelOrder(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/account/dealsandorders", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"orders": [{"orderId": "open", "account": {"id": "1"}, "rawStatus": "ACTIVE", "volume": 10}],
+			"deals": [{"dealId": "d1", "orderId": "filled", "account": {"id": "1"}, "volume": 10}]
+		}`))
+	})
+	mux.HandleFunc("/_mobile/order/stock", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("accountId"))
+		if r.URL.Query().Get("orderId") == "filled" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"order": {"price": 99, "volume": 10, "rawStatus": "ACTIVE", "validUntil": "2030-06-28"}, "account": {"id": "1"}, "orderbook": {"id": "5247"}}`))
+	})
+	mux.HandleFunc("/_api/order", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		if r.URL.Query().Get("orderId") == "open" {
+			writeJSON(w, map[string]string{"orderRequestStatus": "SUCCESS", "orderId": "open"})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/_api/order/stock/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		writeJSON(w, map[string]string{"orderRequestStatus": "ERROR", "message": "order not modifiable"})
+	})
+	var modified map[string]interface{}
+	mux.HandleFunc("/_api/order/stock/keep", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&modified))
+		writeJSON(w, map[string]string{"orderRequestStatus": "SUCCESS", "orderId": "keep"})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	t.Run("Assert that an order is fetched", func(t *testing.T) {
+		order, err := avanza.GetOrder(ctx, InstrumentStock, "1", "open")
+		require.NoError(t, err)
+		assert.Equal(t, "open", order.OrderID)
+		assert.Equal(t, 99.0, order.Price)
+		assert.Equal(t, "5247", order.Orderbook.ID)
+	})
+
+	t.Run("Assert that an open order is cancelled", func(t *testing.T) {
+		result, err := avanza.CancelOrder(ctx, "1", "open")
+		require.NoError(t, err)
+		assert.Equal(t, OrderStatusCancelled, result.Status)
+	})
+
+	t.Run("Assert that cancelling a filled order is reported as filled", func(t *testing.T) {
+		_, err := avanza.CancelOrder(ctx, "1", "filled")
+		assert.ErrorIs(t, err, ErrOrderFilled)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = avanza.CancelOrder(ctx, "1", "unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrOrderFilled)
+	})
+
+	t.Run("Assert that changing only the price keeps the validity of the order", func(t *testing.T) {
+		request := ModifyOrderRequest{AccountID: "1", OrderID: "keep", InstrumentType: InstrumentStock, Price: 102, Volume: 10}
+		_, err := avanza.ModifyOrder(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, 102.0, modified["price"])
+		assert.Equal(t, "2030-06-28", modified["validUntil"])
+	})
+
+	t.Run("Assert that a rejected modification of an open order is not reported as filled", func(t *testing.T) {
+		request := ModifyOrderRequest{AccountID: "1", OrderID: "open", InstrumentType: InstrumentStock, Price: 101, Volume: 5}
+		result, err := avanza.ModifyOrder(ctx, request)
+		assert.ErrorIs(t, err, ErrOrderRejected)
+		assert.NotErrorIs(t, err, ErrOrderFilled)
+		assert.Equal(t, "order not modifiable", result.Message)
+
+		request.OrderID = "filled"
+		_, err = avanza.ModifyOrder(ctx, request)
+		assert.ErrorIs(t, err, ErrOrderFilled)
+
+		request.Volume = 0
+		_, err = avanza.ModifyOrder(ctx, request)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
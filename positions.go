@@ -0,0 +1,137 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// Position is a holding of one instrument in one account.
+type Position struct {
+	AccountID            string                  `json:"accountId"`
+	AccountName          string                  `json:"accountName"`
+	AccountType          string                  `json:"accountType"`
+	OrderbookID          string                  `json:"orderbookId"`
+	Name                 string                  `json:"name"`
+	InstrumentType       internal.InstrumentType `json:"instrumentType"`
+	Currency             string                  `json:"currency"`
+	Volume               float64                 `json:"volume"`
+	AverageAcquiredPrice float64                 `json:"averageAcquiredPrice"`
+	AcquiredValue        float64                 `json:"acquiredValue"`
+	MarketValue          float64                 `json:"value"`
+	Profit               float64                 `json:"profit"`
+	ProfitPercent        float64                 `json:"profitPercent"`
+	LastPrice            float64                 `json:"lastPrice"`
+	Change               float64                 `json:"change"`
+	ChangePercent        float64                 `json:"changePercent"`
+	Tradable             bool                    `json:"tradable"`
+}
+
+// Positions is a list of positions with helpers for grouping them.
+type Positions []Position
+
+// AggregatedPosition is the combined holding of one instrument across accounts.
+type AggregatedPosition struct {
+	OrderbookID          string
+	Name                 string
+	InstrumentType       internal.InstrumentType
+	Currency             string
+	Volume               float64
+	AverageAcquiredPrice float64 // Weighted by volume
+	AcquiredValue        float64
+	MarketValue          float64
+	Profit               float64
+	AccountIDs           []string
+}
+
+// positionsResponse is returned by internal.PositionsPath, positions are
+// grouped by instrument type.
+type positionsResponse struct {
+	InstrumentPositions []struct {
+		InstrumentType internal.InstrumentType `json:"instrumentType"`
+		Positions      []Position              `json:"positions"`
+	} `json:"instrumentPositions"`
+}
+
+// GetPositions returns the positions held in all accounts.
+func (avanza *Avanza) GetPositions(ctx context.Context) (Positions, error) {
+	path, err := internal.PositionsPath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var response positionsResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions Positions
+	for _, group := range response.InstrumentPositions {
+		for _, position := range group.Positions {
+			position.InstrumentType = group.InstrumentType
+			positions = append(positions, position)
+		}
+	}
+
+	return positions, nil
+}
+
+// ByAccount groups the positions by account ID.
+func (p Positions) ByAccount() map[string]Positions {
+	groups := make(map[string]Positions)
+	for _, position := range p {
+		groups[position.AccountID] = append(groups[position.AccountID], position)
+	}
+	return groups
+}
+
+// ByInstrumentType groups the positions by instrument type.
+func (p Positions) ByInstrumentType() map[internal.InstrumentType]Positions {
+	groups := make(map[internal.InstrumentType]Positions)
+	for _, position := range p {
+		groups[position.InstrumentType] = append(groups[position.InstrumentType], position)
+	}
+	return groups
+}
+
+// Aggregate combines positions in the same orderbook held in different
+// accounts, ordered by orderbook ID.
+func (p Positions) Aggregate() []AggregatedPosition {
+	index := make(map[string]int)
+	var aggregated []AggregatedPosition
+
+	for _, position := range p {
+		i, ok := index[position.OrderbookID]
+		if !ok {
+			i = len(aggregated)
+			index[position.OrderbookID] = i
+			aggregated = append(aggregated, AggregatedPosition{
+				OrderbookID:    position.OrderbookID,
+				Name:           position.Name,
+				InstrumentType: position.InstrumentType,
+				Currency:       position.Currency,
+			})
+		}
+
+		a := &aggregated[i]
+		a.Volume += position.Volume
+		a.AcquiredValue += position.AverageAcquiredPrice * position.Volume
+		a.MarketValue += position.MarketValue
+		a.Profit += position.Profit
+		a.AccountIDs = append(a.AccountIDs, position.AccountID)
+	}
+
+	for i := range aggregated {
+		if aggregated[i].Volume != 0 {
+			aggregated[i].AverageAcquiredPrice = aggregated[i].AcquiredValue / aggregated[i].Volume
+		}
+	}
+
+	sort.Slice(aggregated, func(i, j int) bool {
+		return aggregated[i].OrderbookID < aggregated[j].OrderbookID
+	})
+	return aggregated
+}
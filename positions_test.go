@@ -0,0 +1,59 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/account/positions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"instrumentPositions": [
+			{"instrumentType": "STOCK", "positions": [
+				{"accountId": "1", "orderbookId": "5247", "name": "Investor B", "volume": 10, "averageAcquiredPrice": 100, "value": 2000, "profit": 1000, "currency": "SEK"},
+				{"accountId": "2", "orderbookId": "5247", "name": "Investor B", "volume": 30, "averageAcquiredPrice": 200, "value": 6000, "profit": 0, "currency": "SEK"}
+			]},
+			{"instrumentType": "FUND", "positions": [
+				{"accountId": "1", "orderbookId": "878733", "name": "Global Index", "volume": 2.5, "averageAcquiredPrice": 400, "value": 1200, "profit": 200, "currency": "SEK"}
+			]}
+		]}`))
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	positions, err := avanza.GetPositions(context.Background())
+	require.NoError(t, err)
+	require.Len(t, positions, 3)
+
+	t.Run("Assert that positions carry the instrument type of their group", func(t *testing.T) {
+		assert.Equal(t, InstrumentStock, positions[0].InstrumentType)
+		assert.Equal(t, InstrumentFund, positions[2].InstrumentType)
+		assert.Equal(t, 2000.0, positions[0].MarketValue)
+	})
+
+	t.Run("Assert that positions are grouped by account and instrument type", func(t *testing.T) {
+		byAccount := positions.ByAccount()
+		assert.Len(t, byAccount["1"], 2)
+		assert.Len(t, byAccount["2"], 1)
+
+		byType := positions.ByInstrumentType()
+		assert.Len(t, byType[InstrumentStock], 2)
+		assert.Len(t, byType[InstrumentFund], 1)
+	})
+
+	t.Run("Assert that the same instrument is aggregated across accounts", func(t *testing.T) {
+		aggregated := positions.Aggregate()
+		require.Len(t, aggregated, 2)
+
+		investor := aggregated[0]
+		assert.Equal(t, "5247", investor.OrderbookID)
+		assert.Equal(t, 40.0, investor.Volume)
+		assert.Equal(t, 175.0, investor.AverageAcquiredPrice)
+		assert.Equal(t, 8000.0, investor.MarketValue)
+		assert.Equal(t, 1000.0, investor.Profit)
+		assert.Equal(t, []string{"1", "2"}, investor.AccountIDs)
+	})
+}
@@ -0,0 +1,176 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// PriceAlertDirection tells whether an alert fires when the price rises to or
+// falls to the alert price.
+type PriceAlertDirection int
+
+const (
+	PriceAbove PriceAlertDirection = iota
+	PriceBelow
+)
+
+func (d PriceAlertDirection) String() string {
+	switch d {
+	case PriceAbove:
+		return "ABOVE"
+	case PriceBelow:
+		return "BELOW"
+	}
+	return ""
+}
+
+func (d PriceAlertDirection) MarshalJSON() ([]byte, error) {
+	if d.String() == "" {
+		return nil, fmt.Errorf("unknown price alert direction %d", int(d))
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *PriceAlertDirection) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch upper(raw) {
+	case "ABOVE":
+		*d = PriceAbove
+	case "BELOW":
+		*d = PriceBelow
+	default:
+		return fmt.Errorf("unknown price alert direction %q", raw)
+	}
+	return nil
+}
+
+// PriceAlert is an alert on the price of an orderbook.
+type PriceAlert struct {
+	ID          string              `json:"alertId"`
+	OrderbookID string              `json:"orderbookId"`
+	Price       float64             `json:"price"`
+	Direction   PriceAlertDirection `json:"direction"`
+	ValidUntil  Date                `json:"validUntil"`
+	Push        bool                `json:"notification"`
+	Email       bool                `json:"email"`
+}
+
+// priceAlertsResponse is returned by internal.PriceAlertPath.
+type priceAlertsResponse struct {
+	Alerts []PriceAlert `json:"alerts"`
+}
+
+// GetPriceAlerts returns the price alerts for the orderbook with orderbookID.
+func (avanza *Avanza) GetPriceAlerts(ctx context.Context, orderbookID string) ([]PriceAlert, error) {
+	if orderbookID == "" {
+		return nil, fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	}
+
+	path, err := internal.PriceAlertPath.Build(orderbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response priceAlertsResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Alerts, nil
+}
+
+// PriceAlertRequest describes a price alert to create.
+type PriceAlertRequest struct {
+	OrderbookID string
+	Price       float64
+	Direction   PriceAlertDirection
+	ValidUntil  Date // Required, must not be in the past
+	Push        bool // Notify in the app
+	Email       bool // Notify by email
+}
+
+// Validate checks the request before it is sent.
+func (r PriceAlertRequest) Validate() error {
+	switch {
+	case r.OrderbookID == "":
+		return fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	case r.Price <= 0 || math.IsNaN(r.Price) || math.IsInf(r.Price, 0):
+		return fmt.Errorf("%w: price %v must be positive", ErrInvalidArgument, r.Price)
+	case r.Direction.String() == "":
+		return fmt.Errorf("%w: unknown direction %d", ErrInvalidArgument, int(r.Direction))
+	case r.ValidUntil.IsZero():
+		return fmt.Errorf("%w: valid until is required", ErrInvalidArgument)
+	case !r.Push && !r.Email:
+		return fmt.Errorf("%w: push or email notification is required", ErrInvalidArgument)
+	}
+	return validateValidUntil(r.ValidUntil, time.Now())
+}
+
+// createPriceAlertRequest is the body sent to internal.PriceAlertCreatePath.
+type createPriceAlertRequest struct {
+	Price        float64             `json:"price"`
+	Direction    PriceAlertDirection `json:"direction"`
+	ValidUntil   Date                `json:"validUntil"`
+	Notification bool                `json:"notification"`
+	Email        bool                `json:"email"`
+	SMS          bool                `json:"sms"`
+}
+
+// CreatePriceAlert creates a price alert and returns it as stored by Avanza.
+func (avanza *Avanza) CreatePriceAlert(ctx context.Context, request PriceAlertRequest) (*PriceAlert, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	path, err := internal.PriceAlertCreatePath.Build(request.OrderbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := createPriceAlertRequest{
+		Price:        request.Price,
+		Direction:    request.Direction,
+		ValidUntil:   request.ValidUntil,
+		Notification: request.Push,
+		Email:        request.Email,
+	}
+
+	var alert PriceAlert
+	err = avanza.requestJSON(ctx, http.MethodPost, path, data, &alert)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.OrderbookID == "" {
+		alert.OrderbookID = request.OrderbookID
+	}
+	return &alert, nil
+}
+
+// DeletePriceAlert removes the price alert with alertID from the orderbook with orderbookID.
+func (avanza *Avanza) DeletePriceAlert(ctx context.Context, orderbookID, alertID string) error {
+	switch {
+	case orderbookID == "":
+		return fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	case alertID == "":
+		return fmt.Errorf("%w: alert id", ErrInvalidArgument)
+	}
+
+	path, err := internal.PriceAlertDeletePath.Build(orderbookID, alertID)
+	if err != nil {
+		return err
+	}
+
+	return avanza.requestJSON(ctx, http.MethodDelete, path, nil, nil)
+}
@@ -0,0 +1,74 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceAlerts(t *testing.T) {
+	validUntil := NewDate(time.Now().AddDate(0, 1, 0))
+	var deleted string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_cqbe/marketing/service/alert/5247", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"alerts": []map[string]interface{}{
+			{"alertId": "a1", "price": 120.5, "direction": "below", "validUntil": "2030-01-31", "notification": true},
+		}})
+	})
+	mux.HandleFunc("/_cqbe/marketing/service/alert/5247/create", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "ABOVE", body["direction"])
+		assert.Equal(t, validUntil.String(), body["validUntil"])
+		assert.Equal(t, false, body["notification"])
+		assert.Equal(t, true, body["email"])
+		writeJSON(w, map[string]interface{}{"alertId": "a2", "price": body["price"], "direction": "ABOVE"})
+	})
+	mux.HandleFunc("/_cqbe/marketing/service/alert/5247/a1", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.Method
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	request := PriceAlertRequest{OrderbookID: "5247", Price: 150, Direction: PriceAbove, ValidUntil: validUntil, Email: true}
+
+	t.Run("Assert that price alerts are listed", func(t *testing.T) {
+		alerts, err := avanza.GetPriceAlerts(ctx, "5247")
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, PriceBelow, alerts[0].Direction)
+		assert.Equal(t, "2030-01-31", alerts[0].ValidUntil.String())
+		assert.True(t, alerts[0].Push)
+	})
+
+	t.Run("Assert that a price alert is created", func(t *testing.T) {
+		alert, err := avanza.CreatePriceAlert(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, "a2", alert.ID)
+		assert.Equal(t, "5247", alert.OrderbookID)
+		assert.Equal(t, 150.0, alert.Price)
+	})
+
+	t.Run("Assert that invalid price alerts are rejected before they are sent", func(t *testing.T) {
+		invalid := request
+		invalid.Email = false
+		_, err := avanza.CreatePriceAlert(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		invalid = request
+		invalid.ValidUntil = NewDate(time.Now().AddDate(0, 0, -1))
+		_, err = avanza.CreatePriceAlert(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("Assert that a price alert is deleted", func(t *testing.T) {
+		require.NoError(t, avanza.DeletePriceAlert(ctx, "5247", "a1"))
+		assert.Equal(t, http.MethodDelete, deleted)
+	})
+}
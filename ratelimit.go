@@ -0,0 +1,170 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// RouteGroup groups routes that share a rate limit.
+type RouteGroup int
+
+const (
+	MarketDataGroup RouteGroup = iota // Instruments, charts, orderbooks and the push socket
+	TradingGroup                      // Placing, editing and cancelling orders
+	AccountGroup                      // Accounts, positions, transactions and user content
+)
+
+func (g RouteGroup) String() string {
+	switch g {
+	case MarketDataGroup:
+		return "market-data"
+	case TradingGroup:
+		return "trading"
+	case AccountGroup:
+		return "account"
+	}
+	return ""
+}
+
+// routeGroup returns the group a route is rate limited in.
+func routeGroup(route internal.Route) RouteGroup {
+	switch route {
+	case internal.OrderPlacePath,
+		internal.OrderPlaceStopLossPath,
+		internal.OrderPlacePathBuyFund,
+		internal.OrderPlacePathSellFund,
+		internal.OrderEditPath,
+		internal.OrderDeletePath,
+		internal.OrderGetPath,
+		internal.StopLossDeletePath,
+		internal.StopLossPath:
+		return TradingGroup
+	case internal.ChartdataPath,
+		internal.FundPath,
+		internal.InspirationListPath,
+		internal.InstrumentPath,
+		internal.InstrumentDetailsPath,
+		internal.InstrumentSearchPath,
+		internal.OrderbookListPath,
+		internal.OrderbookPath:
+		return MarketDataGroup
+	default:
+		return AccountGroup
+	}
+}
+
+// RateLimit is a token bucket that is refilled with Rate tokens per second
+// and holds at most Burst tokens. Every request takes one token.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// DefaultRateLimits returns conservative limits for all route groups.
+func DefaultRateLimits() map[RouteGroup]RateLimit {
+	return map[RouteGroup]RateLimit{
+		MarketDataGroup: {Rate: 10, Burst: 20},
+		TradingGroup:    {Rate: 2, Burst: 5},
+		AccountGroup:    {Rate: 5, Burst: 10},
+	}
+}
+
+// RateLimitStats describes how a route group has been throttled.
+type RateLimitStats struct {
+	Requests int64         // Tokens taken
+	Waits    int64         // Requests that had to wait for a token
+	WaitTime time.Duration // Total time spent waiting
+}
+
+// RateLimiter throttles requests per route group. A single RateLimiter can
+// be shared by several clients using the same account.
+type RateLimiter struct {
+	sync.Mutex
+	buckets map[RouteGroup]*tokenBucket
+	stats   map[RouteGroup]*RateLimitStats
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter, groups without a limit are not throttled.
+func NewRateLimiter(limits map[RouteGroup]RateLimit) (*RateLimiter, error) {
+	l := &RateLimiter{
+		buckets: make(map[RouteGroup]*tokenBucket),
+		stats:   make(map[RouteGroup]*RateLimitStats),
+	}
+
+	now := time.Now()
+	for group, limit := range limits {
+		if limit.Rate <= 0 || limit.Burst < 1 {
+			return nil, fmt.Errorf("rate limit for %s must have a positive rate and burst", group)
+		}
+		l.buckets[group] = &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.stats[group] = &RateLimitStats{}
+	}
+
+	return l, nil
+}
+
+// Wait blocks until a token is available in group or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, group RouteGroup) error {
+	l.Lock()
+	bucket, ok := l.buckets[group]
+	if !ok {
+		l.Unlock()
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.limit.Rate
+	if bucket.tokens > float64(bucket.limit.Burst) {
+		bucket.tokens = float64(bucket.limit.Burst)
+	}
+	bucket.last = now
+
+	// Reserve the token up front so that concurrent waiters queue up behind each other
+	bucket.tokens--
+	stats := l.stats[group]
+	stats.Requests++
+
+	if bucket.tokens >= 0 {
+		l.Unlock()
+		return nil
+	}
+
+	wait := time.Duration(-bucket.tokens / bucket.limit.Rate * float64(time.Second))
+	stats.Waits++
+	l.Unlock()
+
+	start := time.Now()
+	err := sleep(ctx, wait)
+
+	l.Lock()
+	defer l.Unlock()
+	stats.WaitTime += time.Since(start)
+	if err != nil {
+		// Hand the reservation back
+		bucket.tokens++
+		stats.Requests--
+	}
+	return err
+}
+
+// Stats returns a snapshot of the statistics for every limited group.
+func (l *RateLimiter) Stats() map[RouteGroup]RateLimitStats {
+	l.Lock()
+	defer l.Unlock()
+
+	stats := make(map[RouteGroup]RateLimitStats, len(l.stats))
+	for group, s := range l.stats {
+		stats[group] = *s
+	}
+	return stats
+}
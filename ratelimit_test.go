@@ -0,0 +1,71 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("Assert that requests beyond the burst wait for a token", func(t *testing.T) {
+		limiter, err := NewRateLimiter(map[RouteGroup]RateLimit{TradingGroup: {Rate: 20, Burst: 2}})
+		require.NoError(t, err)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			require.NoError(t, limiter.Wait(context.Background(), TradingGroup))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+		stats := limiter.Stats()[TradingGroup]
+		assert.Equal(t, int64(3), stats.Requests)
+		assert.Equal(t, int64(1), stats.Waits)
+		assert.Greater(t, stats.WaitTime, time.Duration(0))
+	})
+
+	t.Run("Assert that groups without a limit are not throttled", func(t *testing.T) {
+		limiter, err := NewRateLimiter(nil)
+		require.NoError(t, err)
+		assert.NoError(t, limiter.Wait(context.Background(), MarketDataGroup))
+		assert.Empty(t, limiter.Stats())
+	})
+
+	t.Run("Assert that waiting respects context cancellation", func(t *testing.T) {
+		limiter, err := NewRateLimiter(map[RouteGroup]RateLimit{AccountGroup: {Rate: 0.01, Burst: 1}})
+		require.NoError(t, err)
+		require.NoError(t, limiter.Wait(context.Background(), AccountGroup))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, limiter.Wait(ctx, AccountGroup), context.DeadlineExceeded)
+		assert.Equal(t, int64(1), limiter.Stats()[AccountGroup].Requests)
+	})
+
+	t.Run("Assert that invalid limits are rejected", func(t *testing.T) {
+		_, err := NewRateLimiter(map[RouteGroup]RateLimit{AccountGroup: {Rate: 0, Burst: 1}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Assert that requests are throttled in their route group", func(t *testing.T) {
+		limiter, err := NewRateLimiter(DefaultRateLimits())
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {})
+
+		avanza := newTestAvanza(t, mux, Credentials{}, WithRateLimiter(limiter))
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, int64(1), limiter.Stats()[AccountGroup].Requests)
+		assert.Equal(t, TradingGroup, routeGroup(internal.OrderPlacePath))
+		assert.Equal(t, MarketDataGroup, routeGroup(internal.ChartdataPath))
+	})
+}
@@ -0,0 +1,129 @@
+package govanza
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// RetryPolicy controls how requests that fail with a transport error, 429 Too
+// Many Requests or a 5xx status are retried. Only idempotent methods are
+// retried, order placement routes are never retried unless RetryOrders is set.
+// The zero value disables retrying.
+type RetryPolicy struct {
+	MaxRetries     int           // Retries after the first attempt, 0 disables retrying
+	InitialBackoff time.Duration // Backoff before the first retry
+	MaxBackoff     time.Duration // Upper bound for a single backoff, 0 for no bound
+	Multiplier     float64       // Growth of the backoff between retries, at least 1
+	Jitter         float64       // Fraction of the backoff that is randomised, between 0 and 1
+	MaxElapsed     time.Duration // Give up once a retry would end after this much time, 0 for no limit
+	RetryOrders    bool          // Also retry order placement, which risks placing an order twice
+}
+
+// DefaultRetryPolicy returns a policy suitable for background jobs.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+		MaxElapsed:     time.Minute,
+	}
+}
+
+// orderPlacementRoutes are never retried unless RetryPolicy.RetryOrders is set.
+var orderPlacementRoutes = map[internal.Route]bool{
+	internal.OrderPlacePath:         true,
+	internal.OrderPlaceStopLossPath: true,
+	internal.OrderPlacePathBuyFund:  true,
+	internal.OrderPlacePathSellFund: true,
+}
+
+// allows reports whether a request to route with method may be retried.
+func (p RetryPolicy) allows(method string, route internal.Route) bool {
+	if p.MaxRetries <= 0 {
+		return false
+	}
+
+	if orderPlacementRoutes[route] {
+		return p.RetryOrders
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait before retry number attempt, counted from
+// zero. The wait is never shorter than retryAfter.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	multiplier := math.Max(p.Multiplier, 1)
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt))
+	if p.MaxBackoff > 0 {
+		backoff = math.Min(backoff, float64(p.MaxBackoff))
+	}
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	wait := time.Duration(backoff * (1 - jitter*rand.Float64()))
+
+	if wait < retryAfter {
+		return retryAfter
+	}
+	return wait
+}
+
+// isRetryable reports whether err is a temporary failure worth retrying.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var authErr *AuthenticationError
+	if errors.As(err, &authErr) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// retryAfter returns the Retry-After hint carried by err, if any.
+func retryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
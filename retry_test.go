@@ -0,0 +1,113 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		Multiplier:     2,
+	}
+
+	// failingHandler fails with status the first failures times it is called.
+	failingHandler := func(calls *int32, failures int32, status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(calls, 1) <= failures {
+				w.WriteHeader(status)
+			}
+		}
+	}
+
+	t.Run("Assert that GET requests are retried after 5xx", func(t *testing.T) {
+		var calls int32
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OverviewPath.String(), failingHandler(&calls, 2, http.StatusServiceUnavailable))
+
+		avanza := newTestAvanza(t, mux, Credentials{}, WithRetryPolicy(policy))
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Assert that retries stop after MaxRetries", func(t *testing.T) {
+		var calls int32
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OverviewPath.String(), failingHandler(&calls, 10, http.StatusBadGateway))
+
+		avanza := newTestAvanza(t, mux, Credentials{}, WithRetryPolicy(policy))
+		_, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		assert.Error(t, err)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Assert that order placement and client errors are not retried", func(t *testing.T) {
+		var orders, missing int32
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OrderPlacePath.String(), failingHandler(&orders, 10, http.StatusServiceUnavailable))
+		mux.HandleFunc(internal.OverviewPath.String(), failingHandler(&missing, 10, http.StatusNotFound))
+
+		avanza := newTestAvanza(t, mux, Credentials{}, WithRetryPolicy(policy))
+		_, err := avanza.sendRequest(context.Background(), http.MethodPost, mustBuild(t, internal.OrderPlacePath), nil)
+		assert.Error(t, err)
+		_, err = avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&orders))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&missing))
+	})
+
+	t.Run("Assert that order placement is retried when opted in", func(t *testing.T) {
+		var calls int32
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OrderPlacePath.String(), failingHandler(&calls, 1, http.StatusServiceUnavailable))
+
+		optedIn := policy
+		optedIn.RetryOrders = true
+		avanza := newTestAvanza(t, mux, Credentials{}, WithRetryPolicy(optedIn))
+		response, err := avanza.sendRequest(context.Background(), http.MethodPost, mustBuild(t, internal.OrderPlacePath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Assert that the backoff grows, is bounded and respects Retry-After", func(t *testing.T) {
+		policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 3 * time.Second, Multiplier: 2}
+
+		assert.Equal(t, time.Second, policy.backoff(0, 0))
+		assert.Equal(t, 2*time.Second, policy.backoff(1, 0))
+		assert.Equal(t, 3*time.Second, policy.backoff(5, 0))
+		assert.Equal(t, 5*time.Second, policy.backoff(0, 5*time.Second))
+
+		policy.Jitter = 0.5
+		for i := 0; i < 100; i++ {
+			wait := policy.backoff(0, 0)
+			assert.True(t, wait > 500*time.Millisecond && wait <= time.Second, wait)
+		}
+	})
+
+	t.Run("Assert that MaxElapsed stops retrying", func(t *testing.T) {
+		var calls int32
+		mux := http.NewServeMux()
+		mux.HandleFunc(internal.OverviewPath.String(), failingHandler(&calls, 10, http.StatusServiceUnavailable))
+
+		limited := RetryPolicy{MaxRetries: 10, InitialBackoff: time.Hour, MaxElapsed: time.Second}
+		avanza := newTestAvanza(t, mux, Credentials{}, WithRetryPolicy(limited))
+		_, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,86 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// SearchHit is an instrument matching a search.
+type SearchHit struct {
+	OrderbookID    string                  `json:"id"`
+	Name           string                  `json:"name"`
+	Ticker         string                  `json:"tickerSymbol"`
+	ISIN           string                  `json:"isin"`
+	Market         string                  `json:"marketPlace"`
+	Currency       string                  `json:"currency"`
+	FlagCode       string                  `json:"flagCode"`
+	InstrumentType internal.InstrumentType `json:"instrumentType"`
+	LastPrice      float64                 `json:"lastPrice"`
+	ChangePercent  float64                 `json:"changePercent"`
+	Tradable       bool                    `json:"tradable"`
+}
+
+// SearchResults holds the instruments matching a search.
+type SearchResults struct {
+	TotalHits int
+	Hits      []SearchHit
+	Groups    map[internal.InstrumentType][]SearchHit // Only set when searching InstrumentAny
+}
+
+// searchResponse is returned by internal.InstrumentSearchPath, hits are
+// grouped by instrument type.
+type searchResponse struct {
+	TotalNumberOfHits int `json:"totalNumberOfHits"`
+	Hits              []struct {
+		InstrumentType internal.InstrumentType `json:"instrumentType"`
+		NumberOfHits   int                     `json:"numberOfHits"`
+		TopHits        []SearchHit             `json:"topHits"`
+	} `json:"hits"`
+}
+
+// SearchInstruments returns at most limit instruments of instrumentType
+// matching query. Use InstrumentAny to search all instrument types, in which
+// case the hits are also grouped by type.
+func (avanza *Avanza) SearchInstruments(ctx context.Context, query string, instrumentType internal.InstrumentType, limit int) (*SearchResults, error) {
+	query = strings.TrimSpace(query)
+	switch {
+	case query == "":
+		return nil, fmt.Errorf("%w: query", ErrInvalidArgument)
+	case limit < 1:
+		return nil, fmt.Errorf("%w: limit %d must be at least 1", ErrInvalidArgument, limit)
+	case instrumentType != internal.Any && instrumentType.String() == "":
+		return nil, fmt.Errorf("%w: unknown instrument type %d", ErrInvalidArgument, int(instrumentType))
+	}
+
+	path, err := internal.InstrumentSearchPath.Build(instrumentType, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response searchResponse
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &SearchResults{TotalHits: response.TotalNumberOfHits}
+	if instrumentType == internal.Any {
+		results.Groups = make(map[internal.InstrumentType][]SearchHit)
+	}
+
+	for _, group := range response.Hits {
+		for _, hit := range group.TopHits {
+			hit.InstrumentType = group.InstrumentType
+			results.Hits = append(results.Hits, hit)
+			if results.Groups != nil {
+				results.Groups[hit.InstrumentType] = append(results.Groups[hit.InstrumentType], hit)
+			}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,58 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchInstruments(t *testing.T) {
+	var paths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/market/search/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		writeJSON(w, map[string]interface{}{
+			"totalNumberOfHits": 3,
+			"hits": []map[string]interface{}{
+				{"instrumentType": "STOCK", "numberOfHits": 2, "topHits": []map[string]interface{}{
+					{"id": "5269", "name": "Volvo B", "tickerSymbol": "VOLV B", "currency": "SEK"},
+					{"id": "5268", "name": "Volvo A", "tickerSymbol": "VOLV A", "currency": "SEK"},
+				}},
+				{"instrumentType": "CERTIFICATE", "numberOfHits": 1, "topHits": []map[string]interface{}{
+					{"id": "1009", "name": "BULL VOLVO X5"},
+				}},
+			},
+		})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	t.Run("Assert that hits are grouped by type when searching any instrument", func(t *testing.T) {
+		results, err := avanza.SearchInstruments(ctx, "volvo", InstrumentAny, 5)
+		require.NoError(t, err)
+		assert.Equal(t, "/_mobile/market/search/?limit=5&query=volvo", paths[len(paths)-1])
+		assert.Equal(t, 3, results.TotalHits)
+		require.Len(t, results.Hits, 3)
+		assert.Equal(t, "VOLV B", results.Hits[0].Ticker)
+		assert.Len(t, results.Groups[InstrumentStock], 2)
+		assert.Len(t, results.Groups[InstrumentCertificate], 1)
+		assert.Equal(t, InstrumentCertificate, results.Hits[2].InstrumentType)
+	})
+
+	t.Run("Assert that hits are not grouped when searching one instrument type", func(t *testing.T) {
+		results, err := avanza.SearchInstruments(ctx, "volvo b", InstrumentStock, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "/_mobile/market/search/stock?limit=1&query=volvo+b", paths[len(paths)-1])
+		assert.Nil(t, results.Groups)
+	})
+
+	t.Run("Assert that invalid searches are rejected before they are sent", func(t *testing.T) {
+		_, err := avanza.SearchInstruments(ctx, " ", InstrumentStock, 1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = avanza.SearchInstruments(ctx, "volvo", InstrumentStock, 0)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,205 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// Session holds the details of an authenticated Avanza session.
+type Session struct {
+	AuthenticationSession string    // Sent as X-AuthenticationSession on every request
+	SecurityToken         string    // Sent as X-SecurityToken on every request
+	PushSubscriptionID    string    // Used in the push socket handshake
+	CustomerID            string    // The logged in customer
+	RegistrationComplete  bool      // False if the customer has not finished registration
+	LastActivity          time.Time // When the session was last used
+	ExpiresAt             time.Time // When the session lapses unless it is used
+
+	renewable bool // False if the login used a one-off TOTP code
+}
+
+// sessionRenewalMargin is how long before expiry a session is renewed.
+const sessionRenewalMargin = 5 * time.Minute
+
+// Expired reports whether the session has lapsed at the given time.
+func (s Session) Expired(now time.Time) bool {
+	return s.ExpiresAt.IsZero() || !now.Before(s.ExpiresAt)
+}
+
+// authenticationRequest is the body sent to internal.AuthenticationPath.
+type authenticationRequest struct {
+	MaxInactiveMinutes int    `json:"maxInactiveMinutes"`
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+}
+
+// totpRequest is the body sent to internal.TotpPath.
+type totpRequest struct {
+	Method   string `json:"method"`
+	TotpCode string `json:"totpCode"`
+}
+
+// sessionResponse is returned by internal.TotpPath, and by
+// internal.AuthenticationPath when no second factor is required.
+type sessionResponse struct {
+	AuthenticationSession string `json:"authenticationSession"`
+	PushSubscriptionID    string `json:"pushSubscriptionId"`
+	CustomerID            string `json:"customerId"`
+	RegistrationComplete  bool   `json:"registrationComplete"`
+}
+
+// authenticationResponse is returned by internal.AuthenticationPath.
+type authenticationResponse struct {
+	sessionResponse
+	TwoFactorLogin *struct {
+		TransactionID string `json:"transactionId"`
+		Method        string `json:"method"`
+	} `json:"twoFactorLogin"`
+}
+
+// newSession validates a session response and its headers and converts them
+// into a Session that expires after timeout minutes.
+func newSession(route internal.Route, header http.Header, response sessionResponse, timeout int, renewable bool) (Session, error) {
+	missing := func(field string) error {
+		return &AuthenticationError{Route: route, Err: fmt.Errorf("%w: %s", ErrIncompleteSession, field)}
+	}
+
+	switch {
+	case response.AuthenticationSession == "":
+		return Session{}, missing("authenticationSession")
+	case response.PushSubscriptionID == "":
+		return Session{}, missing("pushSubscriptionId")
+	case response.CustomerID == "":
+		return Session{}, missing("customerId")
+	case header.Get("X-SecurityToken") == "":
+		return Session{}, missing("X-SecurityToken")
+	}
+
+	now := time.Now()
+	return Session{
+		AuthenticationSession: response.AuthenticationSession,
+		SecurityToken:         header.Get("X-SecurityToken"),
+		PushSubscriptionID:    response.PushSubscriptionID,
+		CustomerID:            response.CustomerID,
+		RegistrationComplete:  response.RegistrationComplete,
+		LastActivity:          now,
+		ExpiresAt:             now.Add(time.Duration(timeout) * time.Minute),
+		renewable:             renewable,
+	}, nil
+}
+
+// CurrentSession returns a copy of the current authenticated session.
+func (avanza *Avanza) CurrentSession() Session {
+	avanza.sessionMu.RLock()
+	defer avanza.sessionMu.RUnlock()
+	return avanza.auth
+}
+
+// setSession replaces the current session and schedules its renewal.
+func (avanza *Avanza) setSession(auth Session) {
+	avanza.sessionMu.Lock()
+	defer avanza.sessionMu.Unlock()
+	avanza.auth = auth
+	avanza.scheduleRenewal(time.Until(auth.ExpiresAt) - sessionRenewalMargin)
+}
+
+// touchSession records activity on the session, which pushes its expiry forward.
+func (avanza *Avanza) touchSession() {
+	avanza.sessionMu.Lock()
+	defer avanza.sessionMu.Unlock()
+	if avanza.auth.AuthenticationSession == "" {
+		return
+	}
+	now := time.Now()
+	avanza.auth.LastActivity = now
+	avanza.auth.ExpiresAt = now.Add(time.Duration(avanza.AuthenticationTimeout) * time.Minute)
+}
+
+// ensureSession renews the session if it is about to expire.
+func (avanza *Avanza) ensureSession(ctx context.Context) error {
+	auth := avanza.CurrentSession()
+	if auth.AuthenticationSession == "" {
+		return nil
+	}
+
+	if !auth.Expired(time.Now().Add(sessionRenewalMargin)) {
+		return nil
+	}
+
+	if !auth.renewable {
+		if auth.Expired(time.Now()) {
+			return ErrSessionExpired
+		}
+		return nil
+	}
+
+	return avanza.renewSession(ctx, auth.AuthenticationSession)
+}
+
+// renewSession logs in again and re-handshakes the push socket with the new
+// push subscription ID. A failed re-handshake is logged but not returned, as
+// the session itself has been renewed. stale is the authentication session the caller saw,
+// if it has already been replaced by a concurrent renewal nothing is done.
+func (avanza *Avanza) renewSession(ctx context.Context, stale string) error {
+	avanza.renewMu.Lock()
+	defer avanza.renewMu.Unlock()
+
+	if avanza.CurrentSession().AuthenticationSession != stale {
+		return nil
+	}
+
+	auth, err := avanza.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	avanza.setSession(auth)
+
+	avanza.socketMu.Lock()
+	socket := avanza.Socket
+	avanza.socketMu.Unlock()
+
+	// The REST session is already renewed, a broken socket must not fail the caller
+	if socket != nil {
+		if err := socket.Rehandshake(ctx, auth.PushSubscriptionID); err != nil {
+			avanza.logger.Println("Failed to re-handshake push socket after session renewal:", err)
+		}
+	}
+	return nil
+}
+
+// scheduleRenewal renews the session after delay unless it has been used
+// in the meantime, in which case the renewal is pushed back. Callers must
+// hold sessionMu.
+func (avanza *Avanza) scheduleRenewal(delay time.Duration) {
+	if avanza.renewalTimer != nil {
+		avanza.renewalTimer.Stop()
+	}
+
+	if avanza.closed || !avanza.auth.renewable {
+		return
+	}
+
+	avanza.renewalTimer = time.AfterFunc(delay, func() {
+		auth := avanza.CurrentSession()
+
+		remaining := time.Until(auth.ExpiresAt) - sessionRenewalMargin
+		if remaining > 0 {
+			avanza.sessionMu.Lock()
+			avanza.scheduleRenewal(remaining)
+			avanza.sessionMu.Unlock()
+			return
+		}
+
+		// On failure try again shortly, the next request will also attempt a renewal
+		if err := avanza.renewSession(context.Background(), auth.AuthenticationSession); err != nil {
+			avanza.logger.Println("Failed to renew session:", err)
+			avanza.sessionMu.Lock()
+			avanza.scheduleRenewal(time.Minute)
+			avanza.sessionMu.Unlock()
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package govanza
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// loginServer returns a handler that hands out a new authentication session
+// on every login, numbered from 1.
+func loginServer(logins *int32) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc(internal.AuthenticationPath.String(), func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(logins, 1)
+		w.Header().Set("X-SecurityToken", fmt.Sprintf("token-%d", n))
+		writeJSON(w, map[string]interface{}{
+			"authenticationSession": fmt.Sprintf("auth-%d", n),
+			"pushSubscriptionId":    fmt.Sprintf("push-%d", n),
+			"customerId":            "customer",
+		})
+	})
+	return mux
+}
+
+func TestSessionRenewal(t *testing.T) {
+	credentials := Credentials{
+		Username:   "user",
+		Password:   "pass",
+		TOTPSecret: "JBSWY3DPEHPK3PXP",
+	}
+
+	t.Run("Assert that a 401 renews the session and retries the request", func(t *testing.T) {
+		var logins int32
+		mux := loginServer(&logins)
+		mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-AuthenticationSession") == "auth-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "token-2", r.Header.Get("X-SecurityToken"))
+		})
+
+		avanza := newTestAvanza(t, mux, credentials)
+		auth, err := avanza.authenticate(context.Background())
+		require.NoError(t, err)
+		avanza.setSession(auth)
+
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+		assert.Equal(t, "push-2", avanza.CurrentSession().PushSubscriptionID)
+	})
+
+	t.Run("Assert that a failed socket re-handshake does not fail the renewed request", func(t *testing.T) {
+		var logins int32
+		mux := loginServer(&logins)
+		mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-AuthenticationSession") == "auth-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		})
+
+		var logs bytes.Buffer
+		avanza := newTestAvanza(t, mux, credentials, WithLogger(log.New(&logs, "", 0)))
+		avanza.Socket = &internal.AvanzaSocket{
+			Throttle: func(context.Context) error { return errors.New("socket closed") },
+		}
+		auth, err := avanza.authenticate(context.Background())
+		require.NoError(t, err)
+		avanza.setSession(auth)
+
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, "auth-2", avanza.CurrentSession().AuthenticationSession)
+		assert.Contains(t, logs.String(), "socket closed")
+	})
+
+	t.Run("Assert that a session about to expire is renewed before the request", func(t *testing.T) {
+		var logins int32
+		mux := loginServer(&logins)
+		mux.HandleFunc(internal.OverviewPath.String(), func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "auth-2", r.Header.Get("X-AuthenticationSession"))
+		})
+
+		avanza := newTestAvanza(t, mux, credentials, WithAuthenticationTimeout(MinInactiveMinutes))
+		auth, err := avanza.authenticate(context.Background())
+		require.NoError(t, err)
+		auth.ExpiresAt = time.Now().Add(time.Minute)
+		avanza.setSession(auth)
+
+		response, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		session := avanza.CurrentSession()
+		assert.Equal(t, "auth-2", session.AuthenticationSession)
+		assert.WithinDuration(t, time.Now().Add(MinInactiveMinutes*time.Minute), session.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Assert that an expired session with a one-off TOTP code is reported", func(t *testing.T) {
+		avanza := newTestAvanza(t, http.NewServeMux(), Credentials{TOTPCode: "123456"})
+		avanza.setSession(Session{AuthenticationSession: "auth", ExpiresAt: time.Now().Add(-time.Minute)})
+
+		_, err := avanza.sendRequest(context.Background(), http.MethodGet, mustBuild(t, internal.OverviewPath), nil)
+		assert.ErrorIs(t, err, ErrSessionExpired)
+	})
+}
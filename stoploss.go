@@ -0,0 +1,155 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// StopLossResult is the outcome of placing a stop-loss.
+type StopLossResult struct {
+	StopLossID string
+	Status     OrderStatus
+	Message    string // Avanza's explanation if the stop-loss was rejected
+}
+
+// stopLossRequest is the body sent to internal.OrderPlaceStopLossPath.
+type stopLossRequest struct {
+	ParentStopLossID   string                      `json:"parentStopLossId"`
+	AccountID          string                      `json:"accountId"`
+	OrderbookID        string                      `json:"orderBookId"`
+	StopLossTrigger    internal.StopLossTrigger    `json:"stopLossTrigger"`
+	StopLossOrderEvent internal.StopLossOrderEvent `json:"stopLossOrderEvent"`
+}
+
+// stopLossResponse is returned by internal.OrderPlaceStopLossPath.
+type stopLossResponse struct {
+	Status     string `json:"status"`
+	StopLossID string `json:"stoplossOrderId"`
+	Message    string `json:"message"`
+}
+
+// validateStopLoss checks a trigger and order event before they are sent.
+func validateStopLoss(trigger internal.StopLossTrigger, event internal.StopLossOrderEvent, now time.Time) error {
+	switch {
+	case trigger.Type.String() == "":
+		return fmt.Errorf("%w: unknown trigger type %d", ErrInvalidArgument, int(trigger.Type))
+	case trigger.ValueType.String() == "":
+		return fmt.Errorf("%w: unknown trigger value type %d", ErrInvalidArgument, int(trigger.ValueType))
+	case trigger.Value <= 0:
+		return fmt.Errorf("%w: trigger value %v must be positive", ErrInvalidArgument, trigger.Value)
+	case trigger.ValueType == internal.Percentage && trigger.Value > 100:
+		return fmt.Errorf("%w: trigger percentage %v must not exceed 100", ErrInvalidArgument, trigger.Value)
+	case trigger.ValidUntil.IsZero():
+		return fmt.Errorf("%w: trigger valid until is required", ErrInvalidArgument)
+	case NewDate(trigger.ValidUntil).Before(NewDate(now).Time):
+		return fmt.Errorf("%w: trigger valid until %s is in the past", ErrInvalidArgument, NewDate(trigger.ValidUntil))
+	case event.Type.String() == "":
+		return fmt.Errorf("%w: unknown order type %d", ErrInvalidArgument, int(event.Type))
+	case event.PriceType.String() == "":
+		return fmt.Errorf("%w: unknown price type %d", ErrInvalidArgument, int(event.PriceType))
+	case event.PriceType == internal.Percentage && (event.Price < 0 || event.Price > 100):
+		return fmt.Errorf("%w: order price percentage %v must be between 0 and 100", ErrInvalidArgument, event.Price)
+	case event.PriceType == internal.Monetary && event.Price <= 0:
+		return fmt.Errorf("%w: order price %v must be positive", ErrInvalidArgument, event.Price)
+	case event.Volume <= 0:
+		return fmt.Errorf("%w: order volume %v must be positive", ErrInvalidArgument, event.Volume)
+	case event.ValidDays < 1:
+		return fmt.Errorf("%w: order valid days %d must be at least 1", ErrInvalidArgument, event.ValidDays)
+	}
+	return nil
+}
+
+// PlaceStopLoss places a stop-loss that places the order described by event
+// once trigger is met. If Avanza rejects the stop-loss the result is returned
+// together with an error wrapping ErrOrderRejected.
+func (avanza *Avanza) PlaceStopLoss(ctx context.Context, accountID, orderbookID string, trigger internal.StopLossTrigger, event internal.StopLossOrderEvent) (*StopLossResult, error) {
+	switch {
+	case accountID == "":
+		return nil, fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case orderbookID == "":
+		return nil, fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	}
+
+	if err := validateStopLoss(trigger, event, time.Now()); err != nil {
+		return nil, err
+	}
+
+	path, err := internal.OrderPlaceStopLossPath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	data := stopLossRequest{
+		ParentStopLossID:   "0",
+		AccountID:          accountID,
+		OrderbookID:        orderbookID,
+		StopLossTrigger:    trigger,
+		StopLossOrderEvent: event,
+	}
+
+	var response stopLossResponse
+	err = avanza.requestJSON(ctx, http.MethodPost, path, data, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StopLossResult{
+		StopLossID: response.StopLossID,
+		Status:     parseOrderStatus(response.Status),
+		Message:    response.Message,
+	}
+	if result.Status == OrderStatusRejected {
+		return result, fmt.Errorf("%w: %s", ErrOrderRejected, response.Message)
+	}
+	return result, nil
+}
+
+// StopLoss is an active stop-loss.
+type StopLoss struct {
+	ID         string                      `json:"id"`
+	Status     OrderStatus                 `json:"status"`
+	Message    string                      `json:"message"`
+	Account    AccountRef                  `json:"account"`
+	Orderbook  OrderbookRef                `json:"orderbook"`
+	Trigger    internal.StopLossTrigger    `json:"trigger"`
+	OrderEvent internal.StopLossOrderEvent `json:"order"`
+	Editable   bool                        `json:"editable"`
+	Deletable  bool                        `json:"deletable"`
+}
+
+// GetStopLosses returns the stop-losses in all accounts.
+func (avanza *Avanza) GetStopLosses(ctx context.Context) ([]StopLoss, error) {
+	path, err := internal.StopLossPath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var stopLosses []StopLoss
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &stopLosses)
+	if err != nil {
+		return nil, err
+	}
+
+	return stopLosses, nil
+}
+
+// DeleteStopLoss removes the stop-loss with stopLossID from the account with accountID.
+func (avanza *Avanza) DeleteStopLoss(ctx context.Context, accountID, stopLossID string) error {
+	switch {
+	case accountID == "":
+		return fmt.Errorf("%w: account id", ErrInvalidArgument)
+	case stopLossID == "":
+		return fmt.Errorf("%w: stop-loss id", ErrInvalidArgument)
+	}
+
+	path, err := internal.StopLossDeletePath.Build(accountID, stopLossID)
+	if err != nil {
+		return err
+	}
+
+	return avanza.requestJSON(ctx, http.MethodDelete, path, nil, nil)
+}
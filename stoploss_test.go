@@ -0,0 +1,104 @@
+package govanza
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceStopLoss(t *testing.T) {
+	validUntil := time.Now().AddDate(0, 0, 7)
+	trigger := StopLossTrigger{Type: FollowDownwards, Value: 5, ValueType: Percentage, ValidUntil: validUntil}
+	event := StopLossOrderEvent{Type: Sell, Price: 1, Volume: 10, ValidDays: 1, PriceType: Percentage}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/trading-critical/rest/stoploss/new", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			AccountID string                 `json:"accountId"`
+			Trigger   map[string]interface{} `json:"stopLossTrigger"`
+			Event     map[string]interface{} `json:"stopLossOrderEvent"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "1", body.AccountID)
+		assert.Equal(t, "FOLLOW_DOWNWARDS", body.Trigger["type"])
+		assert.Equal(t, "PERCENTAGE", body.Trigger["valueType"])
+		assert.Equal(t, NewDate(validUntil).String(), body.Trigger["validUntil"])
+		assert.Equal(t, "SELL", body.Event["type"])
+		assert.Equal(t, "PERCENTAGE", body.Event["priceType"])
+
+		writeJSON(w, map[string]string{"status": "SUCCESS", "stoplossOrderId": "A2^1"})
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	t.Run("Assert that a stop-loss is placed", func(t *testing.T) {
+		result, err := avanza.PlaceStopLoss(context.Background(), "1", "5247", trigger, event)
+		require.NoError(t, err)
+		assert.Equal(t, "A2^1", result.StopLossID)
+		assert.Equal(t, OrderStatusActive, result.Status)
+	})
+
+	t.Run("Assert that invalid stop-losses are rejected before they are sent", func(t *testing.T) {
+		invalid := []func(*StopLossTrigger, *StopLossOrderEvent){
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { tr.Value = 150 },
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { tr.Value = 0 },
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { tr.ValidUntil = time.Now().AddDate(0, 0, -1) },
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { tr.ValidUntil = time.Time{} },
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { ev.Price = 101 },
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { ev.Volume = 0 },
+			func(tr *StopLossTrigger, ev *StopLossOrderEvent) { ev.ValidDays = 0 },
+		}
+
+		for _, modify := range invalid {
+			tr, ev := trigger, event
+			modify(&tr, &ev)
+			_, err := avanza.PlaceStopLoss(context.Background(), "1", "5247", tr, ev)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		}
+	})
+}
+
+func TestStopLosses(t *testing.T) {
+	var deleted string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_api/trading-critical/rest/stoploss", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{
+			"id": "A2^1", "status": "ACTIVE", "account": {"id": "1"}, "orderbook": {"id": "5247", "type": "STOCK"},
+			"trigger": {"type": "FOLLOW_DOWNWARDS", "value": 5, "valueType": "PERCENTAGE", "validUntil": "2023-06-01"},
+			"order": {"type": "SELL", "price": 1, "volume": 10, "validDays": 8, "priceType": "PERCENTAGE", "priceDecimalPrecision": 2},
+			"deletable": true
+		}]`))
+	})
+	mux.HandleFunc("/_api/trading-critical/rest/stoploss/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		deleted = r.URL.EscapedPath()
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+
+	t.Run("Assert that stop-losses are listed", func(t *testing.T) {
+		stopLosses, err := avanza.GetStopLosses(context.Background())
+		require.NoError(t, err)
+		require.Len(t, stopLosses, 1)
+
+		stopLoss := stopLosses[0]
+		assert.Equal(t, "A2^1", stopLoss.ID)
+		assert.Equal(t, OrderStatusActive, stopLoss.Status)
+		assert.Equal(t, FollowDownwards, stopLoss.Trigger.Type)
+		assert.Equal(t, Percentage, stopLoss.Trigger.ValueType)
+		assert.Equal(t, "2023-06-01", stopLoss.Trigger.ValidUntil.Format("2006-01-02"))
+		assert.Equal(t, Sell, stopLoss.OrderEvent.Type)
+		assert.Equal(t, 8, stopLoss.OrderEvent.ValidDays)
+		assert.True(t, stopLoss.Deletable)
+	})
+
+	t.Run("Assert that a stop-loss is deleted", func(t *testing.T) {
+		require.NoError(t, avanza.DeleteStopLoss(context.Background(), "1", "A2^1"))
+		assert.Equal(t, "/_api/trading-critical/rest/stoploss/1/A2%5E1", deleted)
+
+		assert.ErrorIs(t, avanza.DeleteStopLoss(context.Background(), "1", ""), ErrInvalidArgument)
+	})
+}
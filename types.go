@@ -0,0 +1,210 @@
+package govanza
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// InstrumentType is the type of a financial instrument.
+type InstrumentType = internal.InstrumentType
+
+const (
+	InstrumentStock              = internal.Stock
+	InstrumentFund               = internal.Fund
+	InstrumentBond               = internal.Bond
+	InstrumentOption             = internal.Option
+	InstrumentFutureForward      = internal.FutureForward
+	InstrumentCertificate        = internal.Certificate
+	InstrumentWarrant            = internal.Warrant
+	InstrumentExchangeTradedFund = internal.ExchangeTradedFund
+	InstrumentIndex              = internal.Index
+	InstrumentPremiumBond        = internal.PremiumBond
+	InstrumentSubscriptionOption = internal.SubscriptionOption
+	InstrumentEquityLinkedBond   = internal.EquityLinkedBond
+	InstrumentConvertible        = internal.Convertible
+	InstrumentAny                = internal.Any
+)
+
+// OrderType is the side of an order, Buy or Sell.
+type OrderType = internal.OrderType
+
+const (
+	Buy  = internal.BUY
+	Sell = internal.SELL
+)
+
+// StopLossTrigger decides when a stop-loss is triggered.
+type StopLossTrigger = internal.StopLossTrigger
+
+// StopLossOrderEvent is the order placed when a stop-loss is triggered.
+type StopLossOrderEvent = internal.StopLossOrderEvent
+
+// StopLossTriggerType is how a stop-loss trigger follows the price.
+type StopLossTriggerType = internal.StopLossTriggerType
+
+const (
+	FollowDownwards = internal.FollowDownwards
+	FollowUpwards   = internal.FollowUpwards
+	LessOrEqual     = internal.LessOrEqual
+	MoreOrEqual     = internal.MoreOrEqual
+)
+
+// StopLossPriceType tells whether a stop-loss value is an amount or a percentage.
+type StopLossPriceType = internal.StopLossPriceType
+
+const (
+	Monetary   = internal.Monetary
+	Percentage = internal.Percentage
+)
+
+// TimePeriod is a period to report development or price history over.
+type TimePeriod = internal.TimePeriod
+
+const (
+	PeriodToday             = internal.Today
+	PeriodOneWeek           = internal.OneWeek
+	PeriodOneMonth          = internal.OneMonth
+	PeriodThreeMonths       = internal.ThreeMonths
+	PeriodThisYear          = internal.ThisYear
+	PeriodOneYear           = internal.OneYear
+	PeriodThreeYears        = internal.ThreeYears
+	PeriodFiveYears         = internal.FiveYears
+	PeriodThreeYearsRolling = internal.ThreeYearsRolling
+	PeriodFiveYearsRolling  = internal.FiveYearsRolling
+)
+
+// Resolution is the length of each candle in price history.
+type Resolution = internal.Resolution
+
+const (
+	ResolutionMinute        = internal.Minute
+	ResolutionTwoMinutes    = internal.TwoMinutes
+	ResolutionFiveMinutes   = internal.FiveMinutes
+	ResolutionTenMinutes    = internal.TenMinutes
+	ResolutionThirtyMinutes = internal.ThirtyMinutes
+	ResolutionHour          = internal.Hour
+	ResolutionDay           = internal.Day
+	ResolutionWeek          = internal.Week
+	ResolutionMonth         = internal.Month
+	ResolutionQuarter       = internal.Quarter
+)
+
+// Stockholm is the time zone Avanza reports times in.
+var Stockholm = internal.Stockholm
+
+// timestampLayouts are the formats Avanza uses for points in time.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+}
+
+// Timestamp is a point in time decoded from any of the formats used by
+// Avanza, including milliseconds since the epoch. Times without an offset
+// are taken to be in Stockholm.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var millis int64
+	if err := json.Unmarshal(data, &millis); err == nil {
+		t.Time = time.UnixMilli(millis).In(Stockholm)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	for _, layout := range timestampLayouts {
+		parsed, err := time.ParseInLocation(layout, s, Stockholm)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported timestamp %q", s)
+}
+
+// Date is a calendar day in Stockholm, encoded as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of t in Stockholm.
+func NewDate(t time.Time) Date {
+	year, month, day := t.In(Stockholm).Date()
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, Stockholm)}
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format("2006-01-02")
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+
+	// Some endpoints send a full timestamp where a date is expected
+	parsed, err := time.ParseInLocation("2006-01-02", s[:min(len(s), len("2006-01-02"))], Stockholm)
+	if err != nil {
+		return fmt.Errorf("unsupported date %q", s)
+	}
+	d.Time = parsed
+	return nil
+}
+
+// upper is used when decoding enums sent in varying case.
+func upper(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// Money is an amount in a currency.
+type Money struct {
+	Value    float64 `json:"value"`
+	Currency string  `json:"currency"`
+}
@@ -0,0 +1,158 @@
+package govanza
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/JMrtzsn/govanza/internal"
+)
+
+// Watchlist is a named list of orderbooks.
+type Watchlist struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Editable     bool     `json:"editable"`
+	OrderbookIDs []string `json:"orderbooks"`
+}
+
+// Contains reports whether the watchlist holds the orderbook with orderbookID.
+func (w Watchlist) Contains(orderbookID string) bool {
+	for _, id := range w.OrderbookIDs {
+		if id == orderbookID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWatchlists returns all watchlists of the customer.
+func (avanza *Avanza) GetWatchlists(ctx context.Context) ([]Watchlist, error) {
+	path, err := internal.WatchlistsPath.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var watchlists []Watchlist
+	err = avanza.requestJSON(ctx, http.MethodGet, path, nil, &watchlists)
+	if err != nil {
+		return nil, err
+	}
+
+	return watchlists, nil
+}
+
+// AddToWatchlist adds the orderbook with orderbookID to a watchlist.
+func (avanza *Avanza) AddToWatchlist(ctx context.Context, watchlistID, orderbookID string) error {
+	return avanza.changeWatchlist(ctx, http.MethodPut, watchlistID, orderbookID)
+}
+
+// RemoveFromWatchlist removes the orderbook with orderbookID from a watchlist.
+func (avanza *Avanza) RemoveFromWatchlist(ctx context.Context, watchlistID, orderbookID string) error {
+	return avanza.changeWatchlist(ctx, http.MethodDelete, watchlistID, orderbookID)
+}
+
+func (avanza *Avanza) changeWatchlist(ctx context.Context, method, watchlistID, orderbookID string) error {
+	switch {
+	case watchlistID == "":
+		return fmt.Errorf("%w: watchlist id", ErrInvalidArgument)
+	case orderbookID == "":
+		return fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+	}
+
+	path, err := internal.WatchlistsAddDeletePath.Build(watchlistID, orderbookID)
+	if err != nil {
+		return err
+	}
+
+	return avanza.requestJSON(ctx, method, path, nil, nil)
+}
+
+// WatchlistDiff lists the orderbooks added to and removed from a watchlist.
+type WatchlistDiff struct {
+	Added   []string
+	Removed []string
+}
+
+// Empty reports whether the diff contains no changes.
+func (d WatchlistDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// diffWatchlist returns the changes needed to turn current into desired,
+// sorted by orderbook ID.
+func diffWatchlist(current, desired []string) WatchlistDiff {
+	have := make(map[string]bool, len(current))
+	for _, id := range current {
+		have[id] = true
+	}
+	want := make(map[string]bool, len(desired))
+	for _, id := range desired {
+		want[id] = true
+	}
+
+	var diff WatchlistDiff
+	for id := range want {
+		if !have[id] {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+	for id := range have {
+		if !want[id] {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// SyncWatchlist makes the watchlist with watchlistID hold exactly the
+// orderbooks in desiredOrderbookIDs. The returned diff holds the changes that
+// were applied, which on error are the ones made before the failure.
+func (avanza *Avanza) SyncWatchlist(ctx context.Context, watchlistID string, desiredOrderbookIDs []string) (*WatchlistDiff, error) {
+	if watchlistID == "" {
+		return nil, fmt.Errorf("%w: watchlist id", ErrInvalidArgument)
+	}
+	for _, id := range desiredOrderbookIDs {
+		if id == "" {
+			return nil, fmt.Errorf("%w: orderbook id", ErrInvalidArgument)
+		}
+	}
+
+	watchlists, err := avanza.GetWatchlists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *Watchlist
+	for i := range watchlists {
+		if watchlists[i].ID == watchlistID {
+			current = &watchlists[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: watchlist %s", ErrNotFound, watchlistID)
+	}
+
+	diff := diffWatchlist(current.OrderbookIDs, desiredOrderbookIDs)
+	applied := &WatchlistDiff{}
+
+	for _, id := range diff.Added {
+		if err := avanza.AddToWatchlist(ctx, watchlistID, id); err != nil {
+			return applied, err
+		}
+		applied.Added = append(applied.Added, id)
+	}
+
+	for _, id := range diff.Removed {
+		if err := avanza.RemoveFromWatchlist(ctx, watchlistID, id); err != nil {
+			return applied, err
+		}
+		applied.Removed = append(applied.Removed, id)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,47 @@
+package govanza
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchlists(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_mobile/usercontent/watchlist", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"id": "w1", "name": "Banks", "editable": true, "orderbooks": []string{"5247", "5264"}},
+		})
+	})
+	mux.HandleFunc("/_api/usercontent/watchlist/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/_api/usercontent/watchlist/"))
+	})
+	avanza := newTestAvanza(t, mux, Credentials{})
+	ctx := context.Background()
+
+	t.Run("Assert that watchlists are listed", func(t *testing.T) {
+		watchlists, err := avanza.GetWatchlists(ctx)
+		require.NoError(t, err)
+		require.Len(t, watchlists, 1)
+		assert.Equal(t, "Banks", watchlists[0].Name)
+		assert.True(t, watchlists[0].Contains("5264"))
+	})
+
+	t.Run("Assert that syncing a watchlist applies only the diff", func(t *testing.T) {
+		requests = nil
+		diff, err := avanza.SyncWatchlist(ctx, "w1", []string{"5264", "5361", "5361"})
+		require.NoError(t, err)
+		assert.Equal(t, &WatchlistDiff{Added: []string{"5361"}, Removed: []string{"5247"}}, diff)
+		assert.Equal(t, []string{"PUT w1/orderbooks/5361", "DELETE w1/orderbooks/5247"}, requests)
+	})
+
+	t.Run("Assert that syncing an unknown watchlist is not found", func(t *testing.T) {
+		_, err := avanza.SyncWatchlist(ctx, "w2", nil)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}